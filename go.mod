@@ -0,0 +1,5 @@
+module github.com/ikaros/go-reddit
+
+go 1.18
+
+require golang.org/x/oauth2 v0.26.0
@@ -0,0 +1,517 @@
+package reddit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// AccountService is the API endpoint for the account
+// of the logged-in user.
+type AccountService service
+
+// Me returns the account of the logged-in user.
+func (s *AccountService) Me() (*Account, *Response, error) {
+	return s.MeContext(context.Background())
+}
+
+// MeContext is like Me but uses the given context for the request.
+func (s *AccountService) MeContext(ctx context.Context) (*Account, *Response, error) {
+	account := new(Account)
+	resp, err := s.get(ctx, "/api/v1/me", account)
+	if err != nil {
+		return nil, resp, err
+	}
+	return account, resp, nil
+}
+
+// MessagePolicy restricts who may send private messages.
+type MessagePolicy string
+
+const (
+	MessagePolicyEveryone    MessagePolicy = "everyone"
+	MessagePolicyWhitelisted MessagePolicy = "whitelisted"
+)
+
+// MediaPolicy controls when thumbnails and media previews are shown.
+type MediaPolicy string
+
+const (
+	MediaOn        MediaPolicy = "on"
+	MediaOff       MediaPolicy = "off"
+	MediaSubreddit MediaPolicy = "subreddit"
+)
+
+// Preferences are the settings of the logged-in user. The fields are
+// pointers, so that UpdatePreferences sends only the ones that are set.
+type Preferences struct {
+	// Who may send private messages.
+	AcceptPMs *MessagePolicy `json:"accept_pms,omitempty"`
+
+	// Whether ads are based on the activity on reddit.
+	ActivityRelevantAds *bool `json:"activity_relevant_ads,omitempty"`
+
+	// Whether reddit may track clicks on outbound links.
+	AllowClicktracking *bool `json:"allow_clicktracking,omitempty"`
+
+	// Whether to take part in beta tests.
+	Beta *bool `json:"beta,omitempty"`
+
+	// Whether thumbnails are expanded on click.
+	Clickgadget *bool `json:"clickgadget,omitempty"`
+
+	// Whether read messages are collapsed.
+	CollapseReadMessages *bool `json:"collapse_read_messages,omitempty"`
+
+	// Whether the link list is compressed.
+	Compress *bool `json:"compress,omitempty"`
+
+	// Country code used for content and ads.
+	CountryCode *string `json:"country_code,omitempty"`
+
+	// Whether the gold subscription is renewed automatically.
+	CreditAutorenew *bool `json:"credit_autorenew,omitempty"`
+
+	// Default sort order of comments.
+	DefaultCommentSort *CommentSort `json:"default_comment_sort,omitempty"`
+
+	// Whether additional details are shown in the domain
+	// text of links.
+	DomainDetails *bool `json:"domain_details,omitempty"`
+
+	// Email notifications.
+	EmailChatRequest     *bool `json:"email_chat_request,omitempty"`
+	EmailCommentReply    *bool `json:"email_comment_reply,omitempty"`
+	EmailDigests         *bool `json:"email_digests,omitempty"`
+	EmailMessages        *bool `json:"email_messages,omitempty"`
+	EmailPostReply       *bool `json:"email_post_reply,omitempty"`
+	EmailPrivateMessage  *bool `json:"email_private_message,omitempty"`
+	EmailUnsubscribeAll  *bool `json:"email_unsubscribe_all,omitempty"`
+	EmailUpvoteComment   *bool `json:"email_upvote_comment,omitempty"`
+	EmailUpvotePost      *bool `json:"email_upvote_post,omitempty"`
+	EmailUserNewFollower *bool `json:"email_user_new_follower,omitempty"`
+	EmailUsernameMention *bool `json:"email_username_mention,omitempty"`
+
+	// Whether the default themes of the redesign are used.
+	EnableDefaultThemes *bool `json:"enable_default_themes,omitempty"`
+
+	// Whether other users may follow the profile.
+	EnableFollowers *bool `json:"enable_followers,omitempty"`
+
+	// Whether the home feed contains recommendations.
+	FeedRecommendationsEnabled *bool `json:"feed_recommendations_enabled,omitempty"`
+
+	// Location used for the geo filter of popular links.
+	Geopopular *string `json:"geopopular,omitempty"`
+
+	// Whether ads are hidden, requires gold.
+	HideAds *bool `json:"hide_ads,omitempty"`
+
+	// Whether downvote counts are hidden.
+	HideDowns *bool `json:"hide_downs,omitempty"`
+
+	// Whether search engines may not index the profile.
+	HideFromRobots *bool `json:"hide_from_robots,omitempty"`
+
+	// Whether upvote counts are hidden.
+	HideUps *bool `json:"hide_ups,omitempty"`
+
+	// Whether controversial comments are highlighted.
+	HighlightControversial *bool `json:"highlight_controversial,omitempty"`
+
+	// Whether new comments are highlighted, requires gold.
+	HighlightNewComments *bool `json:"highlight_new_comments,omitempty"`
+
+	// Whether the suggested sort of links is ignored.
+	IgnoreSuggestedSort *bool `json:"ignore_suggested_sort,omitempty"`
+
+	// Whether NSFW links get a label instead of being hidden.
+	LabelNSFW *bool `json:"label_nsfw,omitempty"`
+
+	// Interface language, e.g. "en".
+	Lang *string `json:"lang,omitempty"`
+
+	// Whether the legacy search page is used.
+	LegacySearch *bool `json:"legacy_search,omitempty"`
+
+	// Whether new messages are notified live.
+	LiveOrangereds *bool `json:"live_orangereds,omitempty"`
+
+	// Whether messages are marked as read when the inbox is opened.
+	MarkMessagesRead *bool `json:"mark_messages_read,omitempty"`
+
+	// When thumbnails are shown.
+	Media *MediaPolicy `json:"media,omitempty"`
+
+	// When media previews are shown.
+	MediaPreview *MediaPolicy `json:"media_preview,omitempty"`
+
+	// Comments with a lower score are collapsed.
+	MinCommentScore *int `json:"min_comment_score,omitempty"`
+
+	// Links with a lower score are hidden.
+	MinLinkScore *int `json:"min_link_score,omitempty"`
+
+	// Whether mentions notify the user.
+	MonitorMentions *bool `json:"monitor_mentions,omitempty"`
+
+	// Whether links are opened in a new window.
+	NewWindow *bool `json:"newwindow,omitempty"`
+
+	// Whether night mode is enabled.
+	Nightmode *bool `json:"nightmode,omitempty"`
+
+	// Whether profanity is hidden.
+	NoProfanity *bool `json:"no_profanity,omitempty"`
+
+	// Number of comments shown by default.
+	NumComments *int `json:"num_comments,omitempty"`
+
+	// Number of links shown by default.
+	NumSites *int `json:"numsites,omitempty"`
+
+	// Whether NSFW content may be shown.
+	Over18 *bool `json:"over_18,omitempty"`
+
+	// Whether private RSS feeds are enabled.
+	PrivateFeeds *bool `json:"private_feeds,omitempty"`
+
+	// Whether the profile is hidden from r/all and others.
+	ProfileOptOut *bool `json:"profile_opt_out,omitempty"`
+
+	// Whether votes are public.
+	PublicVotes *bool `json:"public_votes,omitempty"`
+
+	// Whether the account may be used for research.
+	Research *bool `json:"research,omitempty"`
+
+	// Whether NSFW results are included in searches.
+	SearchIncludeOver18 *bool `json:"search_include_over_18,omitempty"`
+
+	// Whether others are notified of cross-posts.
+	SendCrosspostMessages *bool `json:"send_crosspost_messages,omitempty"`
+
+	// Whether welcome messages of subreddits are received.
+	SendWelcomeMessages *bool `json:"send_welcome_messages,omitempty"`
+
+	// Whether user flair is shown.
+	ShowFlair *bool `json:"show_flair,omitempty"`
+
+	// Whether the gold expiration is shown on the profile.
+	ShowGoldExpiration *bool `json:"show_gold_expiration,omitempty"`
+
+	// Whether link flair is shown.
+	ShowLinkFlair *bool `json:"show_link_flair,omitempty"`
+
+	// Whether recommendations are based on the location.
+	ShowLocationBasedRecommendations *bool `json:"show_location_based_recommendations,omitempty"`
+
+	// Whether the online status is shown.
+	ShowPresence *bool `json:"show_presence,omitempty"`
+
+	// Whether promoted links are shown.
+	ShowPromote *bool `json:"show_promote,omitempty"`
+
+	// Whether custom subreddit styles are shown.
+	ShowStylesheets *bool `json:"show_stylesheets,omitempty"`
+
+	// Whether trending subreddits are shown.
+	ShowTrending *bool `json:"show_trending,omitempty"`
+
+	// Whether a link to the twitter account is shown.
+	ShowTwitter *bool `json:"show_twitter,omitempty"`
+
+	// Whether visited links are stored.
+	StoreVisits *bool `json:"store_visits,omitempty"`
+
+	// Third party ads and content personalization.
+	ThirdPartyDataPersonalizedAds         *bool `json:"third_party_data_personalized_ads,omitempty"`
+	ThirdPartyPersonalizedAds             *bool `json:"third_party_personalized_ads,omitempty"`
+	ThirdPartySiteDataPersonalizedAds     *bool `json:"third_party_site_data_personalized_ads,omitempty"`
+	ThirdPartySiteDataPersonalizedContent *bool `json:"third_party_site_data_personalized_content,omitempty"`
+
+	// Whether messages are threaded.
+	ThreadedMessages *bool `json:"threaded_messages,omitempty"`
+
+	// Whether modmail is threaded.
+	ThreadedModmail *bool `json:"threaded_modmail,omitempty"`
+
+	// Whether the subreddits with the most karma
+	// are shown on the profile.
+	TopKarmaSubreddits *bool `json:"top_karma_subreddits,omitempty"`
+
+	// Whether the default subreddits are used.
+	UseGlobalDefaults *bool `json:"use_global_defaults,omitempty"`
+
+	// Whether videos are played automatically.
+	VideoAutoplay *bool `json:"video_autoplay,omitempty"`
+}
+
+// Preferences returns the preferences of the logged-in user.
+func (s *AccountService) Preferences() (*Preferences, *Response, error) {
+	return s.PreferencesContext(context.Background())
+}
+
+// PreferencesContext is like Preferences but uses the given
+// context for the request.
+func (s *AccountService) PreferencesContext(ctx context.Context) (*Preferences, *Response, error) {
+	prefs := new(Preferences)
+	resp, err := s.get(ctx, "/api/v1/me/prefs", prefs)
+	if err != nil {
+		return nil, resp, err
+	}
+	return prefs, resp, nil
+}
+
+// UpdatePreferences changes the preferences of the logged-in user
+// and returns all preferences as stored by reddit. Only the non-nil
+// fields of prefs are sent, the other preferences stay unchanged.
+func (s *AccountService) UpdatePreferences(prefs *Preferences) (*Preferences, *Response, error) {
+	return s.UpdatePreferencesContext(context.Background(), prefs)
+}
+
+// UpdatePreferencesContext is like UpdatePreferences but uses
+// the given context for the request.
+func (s *AccountService) UpdatePreferencesContext(ctx context.Context, prefs *Preferences) (*Preferences, *Response, error) {
+	r, err := s.client.NewRequest("PATCH", "/api/v1/me/prefs", prefs)
+	if err != nil {
+		return nil, nil, err
+	}
+	updated := new(Preferences)
+	resp, err := s.client.DoContext(ctx, r, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+	return updated, resp, nil
+}
+
+// SubredditKarma is the karma the logged-in user
+// earned in a subreddit.
+type SubredditKarma struct {
+	// Name of the subreddit excluding the /r/ prefix.
+	Subreddit string `json:"sr"`
+
+	CommentKarma int `json:"comment_karma"`
+	LinkKarma    int `json:"link_karma"`
+}
+
+// Karma returns the karma of the logged-in user per subreddit.
+func (s *AccountService) Karma() ([]SubredditKarma, *Response, error) {
+	return s.KarmaContext(context.Background())
+}
+
+// KarmaContext is like Karma but uses the given context for the request.
+func (s *AccountService) KarmaContext(ctx context.Context) ([]SubredditKarma, *Response, error) {
+	var list struct {
+		Data []SubredditKarma `json:"data"`
+	}
+	resp, err := s.get(ctx, "/api/v1/me/karma", &list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return list.Data, resp, nil
+}
+
+// Trophy is an award as shown in the trophy case of a user.
+type Trophy struct {
+	Award
+
+	// Time the trophy was granted in UTC epoch-second format.
+	// null for most trophies.
+	GrantedAt *float64 `json:"granted_at"`
+}
+
+// Trophies returns the trophies of the logged-in user.
+func (s *AccountService) Trophies() ([]Trophy, *Response, error) {
+	return s.TrophiesContext(context.Background())
+}
+
+// TrophiesContext is like Trophies but uses the given context
+// for the request.
+func (s *AccountService) TrophiesContext(ctx context.Context) ([]Trophy, *Response, error) {
+	var list struct {
+		Data struct {
+			Trophies []struct {
+				Data Trophy `json:"data"`
+			} `json:"trophies"`
+		} `json:"data"`
+	}
+	resp, err := s.get(ctx, "/api/v1/me/trophies", &list)
+	if err != nil {
+		return nil, resp, err
+	}
+	trophies := make([]Trophy, len(list.Data.Trophies))
+	for i, t := range list.Data.Trophies {
+		trophies[i] = t.Data
+	}
+	return trophies, resp, nil
+}
+
+// Relationship is an entry in one of the user lists of
+// the logged-in user, e.g. a friend or a blocked user.
+type Relationship struct {
+	// Time the relationship was established in
+	// UTC epoch-second format.
+	Date float64 `json:"date"`
+
+	// Fullname of the other account.
+	ID Fullname `json:"id"`
+
+	// Username of the other account.
+	Name string `json:"name"`
+
+	// Fullname of the relationship itself.
+	RelID string `json:"rel_id"`
+
+	// Note on a friend, requires gold. Empty for other relationships.
+	Note string `json:"note"`
+}
+
+// userList is the response of the relationship endpoints.
+type userList struct {
+	Data struct {
+		Children []Relationship `json:"children"`
+	} `json:"data"`
+}
+
+// Friends returns the friends of the logged-in user.
+func (s *AccountService) Friends() ([]Relationship, *Response, error) {
+	return s.FriendsContext(context.Background())
+}
+
+// FriendsContext is like Friends but uses the given context for the request.
+func (s *AccountService) FriendsContext(ctx context.Context) ([]Relationship, *Response, error) {
+	return s.userList(ctx, "/api/v1/me/friends")
+}
+
+// Blocked returns the users the logged-in user blocked.
+func (s *AccountService) Blocked() ([]Relationship, *Response, error) {
+	return s.BlockedContext(context.Background())
+}
+
+// BlockedContext is like Blocked but uses the given context for the request.
+func (s *AccountService) BlockedContext(ctx context.Context) ([]Relationship, *Response, error) {
+	return s.userList(ctx, "/prefs/blocked")
+}
+
+// Trusted returns the users that may always send private
+// messages to the logged-in user.
+func (s *AccountService) Trusted() ([]Relationship, *Response, error) {
+	return s.TrustedContext(context.Background())
+}
+
+// TrustedContext is like Trusted but uses the given context for the request.
+func (s *AccountService) TrustedContext(ctx context.Context) ([]Relationship, *Response, error) {
+	return s.userList(ctx, "/prefs/trusted")
+}
+
+// MessagingRelationships are the users the logged-in user
+// blocked and trusted for private messages.
+type MessagingRelationships struct {
+	Blocked []Relationship
+	Trusted []Relationship
+}
+
+// Messaging returns the blocked and trusted users at once.
+func (s *AccountService) Messaging() (*MessagingRelationships, *Response, error) {
+	return s.MessagingContext(context.Background())
+}
+
+// MessagingContext is like Messaging but uses the given
+// context for the request.
+func (s *AccountService) MessagingContext(ctx context.Context) (*MessagingRelationships, *Response, error) {
+	// Reddit responds with the blocked users
+	// followed by the trusted users.
+	var lists []userList
+	resp, err := s.get(ctx, "/prefs/messaging", &lists)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(lists) != 2 {
+		return nil, resp, fmt.Errorf("Expected 2 user lists, got %d", len(lists))
+	}
+	return &MessagingRelationships{
+		Blocked: lists[0].Data.Children,
+		Trusted: lists[1].Data.Children,
+	}, resp, nil
+}
+
+// Friend returns the friend relationship with the given user.
+func (s *AccountService) Friend(username string) (*Relationship, *Response, error) {
+	return s.FriendContext(context.Background(), username)
+}
+
+// FriendContext is like Friend but uses the given context for the request.
+func (s *AccountService) FriendContext(ctx context.Context, username string) (*Relationship, *Response, error) {
+	rel := new(Relationship)
+	resp, err := s.get(ctx, friendPath(username), rel)
+	if err != nil {
+		return nil, resp, err
+	}
+	return rel, resp, nil
+}
+
+// AddFriend adds the given user as a friend of the logged-in user
+// or updates the note on an existing friend. note may be empty;
+// notes require gold.
+func (s *AccountService) AddFriend(username, note string) (*Relationship, *Response, error) {
+	return s.AddFriendContext(context.Background(), username, note)
+}
+
+// AddFriendContext is like AddFriend but uses the given
+// context for the request.
+func (s *AccountService) AddFriendContext(ctx context.Context, username, note string) (*Relationship, *Response, error) {
+	body := struct {
+		Name string `json:"name"`
+		Note string `json:"note,omitempty"`
+	}{username, note}
+	r, err := s.client.NewRequest("PUT", friendPath(username), body)
+	if err != nil {
+		return nil, nil, err
+	}
+	rel := new(Relationship)
+	resp, err := s.client.DoContext(ctx, r, rel)
+	if err != nil {
+		return nil, resp, err
+	}
+	return rel, resp, nil
+}
+
+// RemoveFriend removes the given user from the friends
+// of the logged-in user.
+func (s *AccountService) RemoveFriend(username string) (*Response, error) {
+	return s.RemoveFriendContext(context.Background(), username)
+}
+
+// RemoveFriendContext is like RemoveFriend but uses the given
+// context for the request.
+func (s *AccountService) RemoveFriendContext(ctx context.Context, username string) (*Response, error) {
+	r, err := s.client.NewRequest("DELETE", friendPath(username), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.DoContext(ctx, r, nil)
+}
+
+func friendPath(username string) string {
+	return "/api/v1/me/friends/" + url.PathEscape(username)
+}
+
+// userList requests the user list at path.
+func (s *AccountService) userList(ctx context.Context, path string) ([]Relationship, *Response, error) {
+	var list userList
+	resp, err := s.get(ctx, path, &list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return list.Data.Children, resp, nil
+}
+
+// get requests path and decodes the response into v.
+func (s *AccountService) get(ctx context.Context, path string, v interface{}) (*Response, error) {
+	r, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.DoContext(ctx, r, v)
+}
@@ -0,0 +1,227 @@
+package reddit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAccountMe(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/me" {
+			t.Errorf("Path was '%s'", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"name": "gopher", "id": "abc", "icon_img": "https://example.com/icon.png",
+			"is_suspended": true, "suspension_expiration_utc": 1500000000.0,
+			"pref_nightmode": true, "pref_geopopular": "DE"}`)
+	})
+	defer ts.Close()
+	me, _, err := client.Account.Me()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if me.Name != "gopher" || me.IconImg != "https://example.com/icon.png" ||
+		!me.PrefNightmode || me.PrefGeopopular != "DE" {
+		t.Errorf("Account was %#v", me)
+	}
+	if !me.IsSuspended || me.SuspensionExpirationUTC == nil || *me.SuspensionExpirationUTC != 1500000000 {
+		t.Errorf("Suspension was %v, %v", me.IsSuspended, me.SuspensionExpirationUTC)
+	}
+}
+
+func TestAccountPreferences(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/me/prefs" {
+			t.Errorf("Path was '%s'", r.URL.Path)
+		}
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"accept_pms": "everyone", "nightmode": false, "numsites": 25,
+				"default_comment_sort": "top", "media": "subreddit"}`)
+		case "PATCH":
+			if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type was '%s'", ct)
+			}
+			body, _ := io.ReadAll(r.Body)
+			if s := strings.TrimSpace(string(body)); s != `{"accept_pms":"whitelisted","nightmode":true}` {
+				t.Errorf("Sent %s", s)
+			}
+			fmt.Fprint(w, `{"accept_pms": "whitelisted", "nightmode": true, "numsites": 25,
+				"default_comment_sort": "top", "media": "subreddit"}`)
+		default:
+			t.Errorf("Method was %s", r.Method)
+		}
+	})
+	defer ts.Close()
+	prefs, _, err := client.Account.Preferences()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *prefs.AcceptPMs != MessagePolicyEveryone || *prefs.Nightmode || *prefs.NumSites != 25 ||
+		*prefs.DefaultCommentSort != CommentSortTop || *prefs.Media != MediaSubreddit || prefs.Beta != nil {
+		t.Errorf("Preferences were %#v", prefs)
+	}
+	nightmode, acceptPMs := true, MessagePolicyWhitelisted
+	updated, _, err := client.Account.UpdatePreferences(&Preferences{
+		Nightmode: &nightmode,
+		AcceptPMs: &acceptPMs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !*updated.Nightmode || *updated.AcceptPMs != MessagePolicyWhitelisted || *updated.NumSites != 25 {
+		t.Errorf("Updated preferences were %#v", updated)
+	}
+}
+
+func TestAccountKarma(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/me/karma" {
+			t.Errorf("Path was '%s'", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"kind": "KarmaList", "data": [
+			{"sr": "golang", "comment_karma": 12, "link_karma": 3},
+			{"sr": "programming", "comment_karma": 1, "link_karma": 0}
+		]}`)
+	})
+	defer ts.Close()
+	karma, _, err := client.Account.Karma()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(karma) != 2 || karma[0] != (SubredditKarma{"golang", 12, 3}) || karma[1].Subreddit != "programming" {
+		t.Errorf("Karma was %#v", karma)
+	}
+}
+
+func TestAccountTrophies(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/me/trophies" {
+			t.Errorf("Path was '%s'", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"kind": "TrophyList", "data": {"trophies": [
+			{"kind": "t6", "data": {"name": "Verified Email", "award_id": "o", "id": null,
+				"icon_70": "https://example.com/70.png", "granted_at": null}},
+			{"kind": "t6", "data": {"name": "Five-Year Club", "award_id": "5", "id": "1q",
+				"granted_at": 1400000000}}
+		]}}`)
+	})
+	defer ts.Close()
+	trophies, _, err := client.Account.Trophies()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trophies) != 2 {
+		t.Fatalf("Trophies were %#v", trophies)
+	}
+	if trophies[0].Name != "Verified Email" || trophies[0].Icon70 != "https://example.com/70.png" ||
+		trophies[0].GrantedAt != nil {
+		t.Errorf("First trophy was %#v", trophies[0])
+	}
+	if trophies[1].ID == nil || *trophies[1].ID != "1q" ||
+		trophies[1].GrantedAt == nil || *trophies[1].GrantedAt != 1400000000 {
+		t.Errorf("Second trophy was %#v", trophies[1])
+	}
+}
+
+func TestAccountUserLists(t *testing.T) {
+	var path string
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		fmt.Fprint(w, `{"kind": "UserList", "data": {"children": [
+			{"date": 1500000000.0, "rel_id": "r9_1", "name": "alice", "id": "t2_a", "note": "bot"}
+		]}}`)
+	})
+	defer ts.Close()
+	a := client.Account
+	for _, test := range []struct {
+		call func() ([]Relationship, *Response, error)
+		path string
+	}{
+		{a.Friends, "/api/v1/me/friends"},
+		{a.Blocked, "/prefs/blocked"},
+		{a.Trusted, "/prefs/trusted"},
+	} {
+		rels, _, err := test.call()
+		if err != nil {
+			t.Errorf("%s: %s", test.path, err)
+			continue
+		}
+		if path != test.path {
+			t.Errorf("Requested %s instead of %s", path, test.path)
+		}
+		want := Relationship{Date: 1500000000, ID: "t2_a", Name: "alice", RelID: "r9_1", Note: "bot"}
+		if len(rels) != 1 || rels[0] != want {
+			t.Errorf("%s returned %#v", test.path, rels)
+		}
+	}
+}
+
+func TestAccountMessaging(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/prefs/messaging" {
+			t.Errorf("Path was '%s'", r.URL.Path)
+		}
+		fmt.Fprint(w, `[
+			{"kind": "UserList", "data": {"children": [{"name": "spammer", "id": "t2_s"}]}},
+			{"kind": "UserList", "data": {"children": [{"name": "alice", "id": "t2_a"}, {"name": "bob", "id": "t2_b"}]}}
+		]`)
+	})
+	defer ts.Close()
+	rels, _, err := client.Account.Messaging()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rels.Blocked) != 1 || rels.Blocked[0].Name != "spammer" ||
+		len(rels.Trusted) != 2 || rels.Trusted[1].ID != "t2_b" {
+		t.Errorf("Relationships were %#v", rels)
+	}
+}
+
+func TestAccountFriend(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/me/friends/alice" {
+			t.Errorf("Path was '%s'", r.URL.Path)
+		}
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"date": 1500000000.0, "name": "alice", "id": "t2_a"}`)
+		case "PUT":
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body["name"] != "alice" || body["note"] != "met at gophercon" {
+				t.Errorf("Sent %v", body)
+			}
+			fmt.Fprintf(w, `{"date": 1500000000.0, "name": "alice", "id": "t2_a", "note": "%s"}`, body["note"])
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	defer ts.Close()
+	rel, _, err := client.Account.Friend("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Name != "alice" || rel.Date != 1500000000 {
+		t.Errorf("Friend was %#v", rel)
+	}
+	rel, _, err = client.Account.AddFriend("alice", "met at gophercon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Note != "met at gophercon" {
+		t.Errorf("Friend was %#v", rel)
+	}
+	resp, err := client.Account.RemoveFriend("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Status was %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,139 @@
+package reddit
+
+import "errors"
+
+// SkipReplies can be returned by the function passed to Walk or
+// WalkBreadthFirst to skip the replies of the current comment.
+var SkipReplies = errors.New("skip replies")
+
+// WalkFunc is called for every comment visited by Walk or
+// WalkBreadthFirst. depth is 0 for the comments of the walked
+// Replies, 1 for their replies and so on. If it returns SkipReplies,
+// the replies of c are not visited, any other error stops the walk.
+type WalkFunc func(c *Comment, depth int) error
+
+// Walk visits the comment tree r depth-first, i.e. every comment
+// is visited before its replies.
+func (r *Replies) Walk(fn WalkFunc) error {
+	err := r.walk(fn, 0)
+	if err == SkipReplies {
+		return nil
+	}
+	return err
+}
+
+func (r *Replies) walk(fn WalkFunc, depth int) error {
+	for _, c := range r.Comments {
+		err := fn(c, depth)
+		if err == SkipReplies {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := c.Replies.walk(fn, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WalkBreadthFirst visits the comment tree r level by level.
+func (r *Replies) WalkBreadthFirst(fn WalkFunc) error {
+	level := r.Comments
+	for depth := 0; len(level) > 0; depth++ {
+		var next []*Comment
+		for _, c := range level {
+			err := fn(c, depth)
+			if err == SkipReplies {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			next = append(next, c.Replies.Comments...)
+		}
+		level = next
+	}
+	return nil
+}
+
+// Flatten returns all comments of the tree r in depth-first order,
+// so every comment follows its parent.
+func (r *Replies) Flatten() []*Comment {
+	return r.Filter(func(*Comment) bool { return true })
+}
+
+// Count returns the number of loaded comments in the tree r.
+// Comments hidden behind More placeholders are not counted.
+func (r *Replies) Count() int {
+	n := 0
+	r.Walk(func(*Comment, int) error {
+		n++
+		return nil
+	})
+	return n
+}
+
+// Find returns the comment with the given fullname, or nil.
+func (r *Replies) Find(name Fullname) *Comment {
+	var found *Comment
+	r.Walk(func(c *Comment, _ int) error {
+		if c.Name == name {
+			found = c
+			return errStopWalk
+		}
+		return nil
+	})
+	return found
+}
+
+// PathToRoot returns the comment with the given fullname followed by
+// its parents up to a comment of r. It returns nil if the comment is
+// not part of the tree.
+func (r *Replies) PathToRoot(name Fullname) []*Comment {
+	var path []*Comment
+	r.Walk(func(c *Comment, depth int) error {
+		path = append(path[:depth], c)
+		if c.Name == name {
+			return errStopWalk
+		}
+		return nil
+	})
+	if len(path) == 0 || path[len(path)-1].Name != name {
+		return nil
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// errStopWalk stops a walk early without being returned.
+var errStopWalk = errors.New("stop walk")
+
+// CommentFilter reports whether a comment should be kept by Filter.
+type CommentFilter func(c *Comment) bool
+
+// Filter returns the comments of the tree r for which keep returns
+// true, in depth-first order.
+func (r *Replies) Filter(keep CommentFilter) []*Comment {
+	var comments []*Comment
+	r.Walk(func(c *Comment, _ int) error {
+		if keep(c) {
+			comments = append(comments, c)
+		}
+		return nil
+	})
+	return comments
+}
+
+// ByAuthor keeps comments of the given author.
+func ByAuthor(author string) CommentFilter {
+	return func(c *Comment) bool { return c.Author == author }
+}
+
+// ScoreAbove keeps comments with a score above n.
+func ScoreAbove(n int) CommentFilter {
+	return func(c *Comment) bool { return c.Score > n }
+}
@@ -0,0 +1,114 @@
+package reddit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testTree returns the tree
+//
+//	a
+//	├── b
+//	│   └── d
+//	└── c
+//	e
+func testTree() *Replies {
+	c := func(name, author string, score int, replies ...*Comment) *Comment {
+		return &Comment{
+			Name:    Fullname("t1_" + name),
+			Author:  author,
+			Score:   score,
+			Replies: Replies{Comments: replies},
+		}
+	}
+	return &Replies{Comments: []*Comment{
+		c("a", "alice", 10,
+			c("b", "bob", 5, c("d", "alice", 1)),
+			c("c", "carol", 20)),
+		c("e", "bob", -3),
+	}}
+}
+
+func names(comments []*Comment) string {
+	s := make([]string, len(comments))
+	for i, c := range comments {
+		s[i] = c.Name.ID()
+	}
+	return strings.Join(s, ",")
+}
+
+func TestRepliesWalk(t *testing.T) {
+	var visited []string
+	err := testTree().Walk(func(c *Comment, depth int) error {
+		visited = append(visited, fmt.Sprintf("%s%d", c.Name.ID(), depth))
+		if c.Name == "t1_b" {
+			return SkipReplies
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := strings.Join(visited, ","); s != "a0,b1,c1,e0" {
+		t.Errorf("Visited %s instead of a0,b1,c1,e0", s)
+	}
+	stop := errors.New("stop")
+	if err := testTree().Walk(func(*Comment, int) error { return stop }); err != stop {
+		t.Errorf("Walk returned '%v' instead of the error of fn", err)
+	}
+}
+
+func TestRepliesWalkBreadthFirst(t *testing.T) {
+	var visited []string
+	testTree().WalkBreadthFirst(func(c *Comment, depth int) error {
+		visited = append(visited, fmt.Sprintf("%s%d", c.Name.ID(), depth))
+		return nil
+	})
+	if s := strings.Join(visited, ","); s != "a0,e0,b1,c1,d2" {
+		t.Errorf("Visited %s instead of a0,e0,b1,c1,d2", s)
+	}
+}
+
+func TestRepliesFlattenAndCount(t *testing.T) {
+	tree := testTree()
+	if s := names(tree.Flatten()); s != "a,b,d,c,e" {
+		t.Errorf("Flattened to %s instead of a,b,d,c,e", s)
+	}
+	if n := tree.Count(); n != 5 {
+		t.Errorf("Count was %d instead of 5", n)
+	}
+	if n := tree.Comments[0].Replies.Count(); n != 3 {
+		t.Errorf("Count of a's replies was %d instead of 3", n)
+	}
+}
+
+func TestRepliesFind(t *testing.T) {
+	tree := testTree()
+	if c := tree.Find("t1_d"); c == nil || c.Score != 1 {
+		t.Errorf("Found %#v for t1_d", c)
+	}
+	if c := tree.Find("t1_x"); c != nil {
+		t.Errorf("Found %#v for t1_x", c)
+	}
+	if s := names(tree.PathToRoot("t1_d")); s != "d,b,a" {
+		t.Errorf("Path of t1_d was %s instead of d,b,a", s)
+	}
+	if s := names(tree.PathToRoot("t1_e")); s != "e" {
+		t.Errorf("Path of t1_e was %s instead of e", s)
+	}
+	if path := tree.PathToRoot("t1_x"); path != nil {
+		t.Errorf("Path of t1_x was %s", names(path))
+	}
+}
+
+func TestRepliesFilter(t *testing.T) {
+	tree := testTree()
+	if s := names(tree.Filter(ByAuthor("alice"))); s != "a,d" {
+		t.Errorf("Comments by alice were %s instead of a,d", s)
+	}
+	if s := names(tree.Filter(ScoreAbove(5))); s != "a,c" {
+		t.Errorf("Comments above 5 were %s instead of a,c", s)
+	}
+}
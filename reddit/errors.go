@@ -0,0 +1,144 @@
+package reddit
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONError is a single entry of the `json.errors` array reddit's API
+// endpoints use to report failures, e.g.
+//
+//	["SUBREDDIT_NOEXIST", "that subreddit doesn't exist", "sr"]
+type JSONError struct {
+	// Error code, e.g. "SUBREDDIT_NOEXIST"
+	Code string
+
+	// Human readable description of the error
+	Message string
+
+	// Name of the request field that caused the error, if any
+	Field string
+
+	// For RATELIMIT errors, the time to wait before trying again
+	RetryAfter time.Duration
+}
+
+func (e *JSONError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("%s: %s (%s)", e.Code, e.Message, e.Field)
+}
+
+// Is reports whether target is a *JSONError with the same Code,
+// which allows to use errors.Is with sentinels like ErrRateLimit.
+func (e *JSONError) Is(target error) bool {
+	t, ok := target.(*JSONError)
+	return ok && t.Code == e.Code
+}
+
+// Sentinels for common error codes, to be used with errors.Is.
+var (
+	ErrRateLimit        = &JSONError{Code: "RATELIMIT"}
+	ErrUserRequired     = &JSONError{Code: "USER_REQUIRED"}
+	ErrSubredditNoExist = &JSONError{Code: "SUBREDDIT_NOEXIST"}
+	ErrAlreadySub       = &JSONError{Code: "ALREADY_SUB"}
+	ErrUserDoesntExist  = &JSONError{Code: "USER_DOESNT_EXIST"}
+	ErrNoText           = &JSONError{Code: "NO_TEXT"}
+	ErrNoSubject        = &JSONError{Code: "NO_SUBJECT"}
+)
+
+// JSONErrors is returned if reddit reported one or more errors in
+// the `json.errors` array of a response. Reddit does so even for
+// responses with a 2xx status code.
+type JSONErrors []*JSONError
+
+func (e JSONErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether any of the errors matches target.
+func (e JSONErrors) Is(target error) bool {
+	for _, err := range e {
+		if err.Is(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As sets a **JSONError target to the first error.
+func (e JSONErrors) As(target interface{}) bool {
+	t, ok := target.(**JSONError)
+	if !ok || len(e) == 0 {
+		return false
+	}
+	*t = e[0]
+	return true
+}
+
+// jsonErrorsFromBody parses the `json.errors` array of a response body.
+// It returns nil if the body is no JSON or reports no errors.
+func jsonErrorsFromBody(body []byte) JSONErrors {
+	var envelope struct {
+		JSON *struct {
+			Errors [][]interface{} `json:"errors"`
+
+			// Seconds to wait, sent along with RATELIMIT errors
+			Ratelimit float64 `json:"ratelimit"`
+		} `json:"json"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil
+	}
+	if envelope.JSON == nil || len(envelope.JSON.Errors) == 0 {
+		return nil
+	}
+	errs := make(JSONErrors, len(envelope.JSON.Errors))
+	for i, triple := range envelope.JSON.Errors {
+		e := &JSONError{}
+		for j, dst := range []*string{&e.Code, &e.Message, &e.Field} {
+			if j < len(triple) {
+				*dst, _ = triple[j].(string)
+			}
+		}
+		if e.Code == ErrRateLimit.Code {
+			e.RetryAfter = time.Duration(envelope.JSON.Ratelimit * float64(time.Second))
+			if e.RetryAfter <= 0 {
+				e.RetryAfter = retryAfterFromMessage(e.Message)
+			}
+		}
+		errs[i] = e
+	}
+	return errs
+}
+
+var retryAfterPattern = regexp.MustCompile(`try again in (\d+) (millisecond|second|minute|hour)`)
+
+// retryAfterFromMessage parses the wait duration of RATELIMIT messages
+// like "you are doing that too much. try again in 5 minutes."
+func retryAfterFromMessage(msg string) time.Duration {
+	m := retryAfterPattern.FindStringSubmatch(msg)
+	if m == nil {
+		return 0
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0
+	}
+	unit := map[string]time.Duration{
+		"millisecond": time.Millisecond,
+		"second":      time.Second,
+		"minute":      time.Minute,
+		"hour":        time.Hour,
+	}[m[2]]
+	return time.Duration(n) * unit
+}
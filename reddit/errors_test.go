@@ -0,0 +1,99 @@
+package reddit
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckResponseJSONErrors(t *testing.T) {
+	for _, status := range []int{http.StatusOK, http.StatusBadRequest} {
+		err := CheckResponse(&http.Response{
+			StatusCode: status,
+			Body: ioutil.NopCloser(strings.NewReader(`{"json": {"errors": [
+				["SUBREDDIT_NOEXIST", "that subreddit doesn't exist", "sr"],
+				["RATELIMIT", "you are doing that too much", null]
+			]}}`)),
+		})
+		errs, ok := err.(JSONErrors)
+		if !ok {
+			t.Fatalf("Returned '%#v' instead of JSONErrors", err)
+		}
+		should := JSONErrors{
+			{Code: "SUBREDDIT_NOEXIST", Message: "that subreddit doesn't exist", Field: "sr"},
+			{Code: "RATELIMIT", Message: "you are doing that too much"},
+		}
+		if !reflect.DeepEqual(errs, should) {
+			t.Errorf("Returned '%#v' instead of '%#v'", errs, should)
+		}
+	}
+}
+
+func TestCheckResponseNoJSONErrors(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"json": {"errors": []}}`)),
+	}
+	if err := CheckResponse(resp); err != nil {
+		t.Error("Returned error for an empty errors array: ", err)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(body); s != `{"json": {"errors": []}}` {
+		t.Errorf("Body was not restored, but '%s'", s)
+	}
+}
+
+func TestJSONErrorsIs(t *testing.T) {
+	var err error = JSONErrors{
+		{Code: "BAD_SR_NAME"},
+		{Code: "SUBREDDIT_NOEXIST", Message: "that subreddit doesn't exist", Field: "sr"},
+	}
+	err = fmt.Errorf("submit: %w", err)
+	if !errors.Is(err, ErrSubredditNoExist) {
+		t.Error("errors.Is doesn't match ErrSubredditNoExist")
+	}
+	if errors.Is(err, ErrRateLimit) {
+		t.Error("errors.Is matches ErrRateLimit")
+	}
+	var jsonErr *JSONError
+	if !errors.As(err, &jsonErr) || jsonErr.Code != "BAD_SR_NAME" {
+		t.Errorf("errors.As returned '%#v'", jsonErr)
+	}
+}
+
+func TestClientDoJSONErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"json": {"errors": [["USER_REQUIRED", "please login to do that", null]]}}`)
+	}))
+	defer ts.Close()
+	req, err := http.NewRequest("POST", ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = NewClient(nil).Do(req, nil)
+	if !errors.Is(err, ErrUserRequired) {
+		t.Errorf("Returned '%#v' instead of ErrUserRequired", err)
+	}
+}
+
+func TestRetryAfterFromMessage(t *testing.T) {
+	for msg, should := range map[string]time.Duration{
+		"you are doing that too much. try again in 5 minutes.":  5 * time.Minute,
+		"you are doing that too much. try again in 1 minute.":   time.Minute,
+		"you are doing that too much. try again in 42 seconds.": 42 * time.Second,
+		"something else": 0,
+	} {
+		if d := retryAfterFromMessage(msg); d != should {
+			t.Errorf("Parsed %v instead of %v from '%s'", d, should, msg)
+		}
+	}
+}
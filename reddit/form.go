@@ -0,0 +1,141 @@
+package reddit
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// encodeValues converts v into url.Values. v is either url.Values or
+// a struct, or pointer to a struct, whose fields are tagged like
+//
+//	Kind     string   `url:"kind"`
+//	NSFW     bool     `url:"nsfw,omitempty"`
+//	Children []string `url:"children,omitempty"`
+//
+// Fields without a tag or tagged with "-" are skipped, embedded
+// structs are flattened. Zero values are omitted if the tag has the
+// omitempty option. Nil pointers are always omitted, which allows to
+// send explicit false or 0 values through *bool or *int fields.
+// Slices are joined with commas, as reddit expects for lists of IDs.
+func encodeValues(v interface{}) (url.Values, error) {
+	if values, ok := v.(url.Values); ok {
+		return values, nil
+	}
+	values := url.Values{}
+	if v == nil {
+		return values, nil
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return values, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Can't encode %T as url values", v)
+	}
+	return values, addStructValues(values, rv)
+}
+
+func addStructValues(values url.Values, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fv := rv.Field(i)
+		tag := field.Tag.Get("url")
+		if field.Anonymous && tag == "" {
+			for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := addStructValues(values, fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if tag == "" || tag == "-" || field.PkgPath != "" {
+			continue
+		}
+		name, opts := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, opts = tag[:i], tag[i+1:]
+		}
+		omitEmpty := opts == "omitempty"
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+			omitEmpty = false
+		}
+		if omitEmpty && isZeroValue(fv) {
+			continue
+		}
+		s, err := formatValue(fv)
+		if err != nil {
+			return fmt.Errorf("Field %s: %s", field.Name, err)
+		}
+		values.Set(name, s)
+	}
+	return nil
+}
+
+func formatValue(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			s, err := formatValue(v.Index(i))
+			if err != nil {
+				return "", err
+			}
+			parts[i] = s
+		}
+		return strings.Join(parts, ","), nil
+	}
+	return "", fmt.Errorf("Unsupported type %s", v.Type())
+}
+
+func isZeroValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
+// addOptions adds the url values of opts to the query of urlStr.
+func addOptions(urlStr string, opts interface{}) (string, error) {
+	values, err := encodeValues(opts)
+	if err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return urlStr, nil
+	}
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	for k, v := range values {
+		q[k] = v
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
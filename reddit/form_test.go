@@ -0,0 +1,97 @@
+package reddit
+
+import (
+	"io/ioutil"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type formTestEmbedded struct {
+	APIType string `url:"api_type"`
+}
+
+type formTestStruct struct {
+	formTestEmbedded
+	Kind      string   `url:"kind"`
+	Text      string   `url:"text,omitempty"`
+	NSFW      bool     `url:"nsfw,omitempty"`
+	Resubmit  *bool    `url:"resubmit,omitempty"`
+	Dir       int      `url:"dir"`
+	Children  []string `url:"children,omitempty"`
+	Untagged  string
+	Skipped   string `url:"-"`
+	unexposed string `url:"unexposed"`
+}
+
+func TestEncodeValuesStruct(t *testing.T) {
+	resubmit := false
+	values, err := encodeValues(&formTestStruct{
+		formTestEmbedded: formTestEmbedded{APIType: "json"},
+		Kind:             "self",
+		Resubmit:         &resubmit,
+		Dir:              -1,
+		Children:         []string{"c1", "c2"},
+		Untagged:         "foo",
+		Skipped:          "bar",
+		unexposed:        "baz",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	should := url.Values{
+		"api_type": {"json"},
+		"kind":     {"self"},
+		"resubmit": {"false"},
+		"dir":      {"-1"},
+		"children": {"c1,c2"},
+	}
+	if !reflect.DeepEqual(values, should) {
+		t.Errorf("Values were %#v instead of %#v", values, should)
+	}
+}
+
+func TestEncodeValuesURLValues(t *testing.T) {
+	v := url.Values{"foo": {"bar"}}
+	values, err := encodeValues(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, v) {
+		t.Errorf("Values were %#v instead of %#v", values, v)
+	}
+}
+
+func TestEncodeValuesUnsupported(t *testing.T) {
+	if _, err := encodeValues("foo"); err == nil {
+		t.Error("No error for a string")
+	}
+	if _, err := encodeValues(struct {
+		M map[string]string `url:"m"`
+	}{}); err == nil {
+		t.Error("No error for a map field")
+	}
+}
+
+func TestNewFormRequest(t *testing.T) {
+	req, err := NewClient(nil).NewFormRequest("POST", "/api/vote", url.Values{
+		"id":  {"t3_asdf"},
+		"dir": {"1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct := req.Header.Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+		t.Errorf("Content-Type was '%s'", ct)
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(body); s != "dir=1&id=t3_asdf" {
+		t.Errorf("Body was '%s' instead of 'dir=1&id=t3_asdf'", s)
+	}
+	if req.GetBody == nil {
+		t.Error("Request body can't be restored for retries")
+	}
+}
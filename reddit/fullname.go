@@ -0,0 +1,148 @@
+package reddit
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Fullname combines the kind of a thing and its base36 ID,
+// e.g. "t3_15bfi0" for the link with the ID "15bfi0".
+type Fullname string
+
+// NewFullname returns the fullname of the thing of kind k with the
+// given base36 ID.
+func NewFullname(k Kind, id string) (Fullname, error) {
+	f := Fullname(string(k) + "_" + id)
+	if err := f.Validate(); err != nil {
+		return "", err
+	}
+	return f, nil
+}
+
+// FullnameFromInt64 returns the fullname of the thing of kind k
+// with the given ID in base 10.
+func FullnameFromInt64(k Kind, id int64) Fullname {
+	return Fullname(string(k) + "_" + strconv.FormatInt(id, 36))
+}
+
+// ParseFullname parses and validates s.
+func ParseFullname(s string) (Fullname, error) {
+	f := Fullname(s)
+	if err := f.Validate(); err != nil {
+		return "", err
+	}
+	return f, nil
+}
+
+// FullnameFromURL extracts the fullname of the thing a reddit URL
+// points to. Supported are permalinks of links and comments, like
+// "https://www.reddit.com/r/golang/comments/5572gp/title/d87rbux/"
+// or their relative form, and short URLs like "https://redd.it/5572gp".
+func FullnameFromURL(rawurl string) (Fullname, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "", err
+	}
+	host := strings.ToLower(u.Host)
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+	if host == "redd.it" {
+		if len(segments) != 1 {
+			return "", fmt.Errorf("%s is no short URL of a link", rawurl)
+		}
+		return NewFullname(KindLink, segments[0])
+	}
+	if host != "" && host != "reddit.com" && !strings.HasSuffix(host, ".reddit.com") {
+		return "", fmt.Errorf("%s is no reddit URL", rawurl)
+	}
+	for i, s := range segments {
+		if s != "comments" || i+1 >= len(segments) {
+			continue
+		}
+		// comments/{link}/{title}/{comment}
+		if i+3 < len(segments) {
+			return NewFullname(KindComment, segments[i+3])
+		}
+		return NewFullname(KindLink, segments[i+1])
+	}
+	return "", fmt.Errorf("%s is no permalink", rawurl)
+}
+
+// Validate checks that f consists of a known kind and a base36 ID.
+func (f Fullname) Validate() error {
+	i := strings.IndexByte(string(f), '_')
+	if i < 0 {
+		return fmt.Errorf("%q is no fullname: missing '_'", string(f))
+	}
+	switch Kind(f[:i]) {
+	case KindComment, KindAccount, KindLink, KindMessage,
+		KindSubreddit, KindAward, KindPromoCampaign:
+	default:
+		return fmt.Errorf("%q is no fullname: unknown kind %q", string(f), string(f[:i]))
+	}
+	id := string(f[i+1:])
+	if id == "" {
+		return fmt.Errorf("%q is no fullname: missing ID", string(f))
+	}
+	for _, r := range id {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'z') {
+			return fmt.Errorf("%q is no fullname: ID is not base36", string(f))
+		}
+	}
+	if _, err := strconv.ParseInt(id, 36, 64); err != nil {
+		return fmt.Errorf("%q is no fullname: ID out of range", string(f))
+	}
+	return nil
+}
+
+// validateKind checks that f is a valid fullname of one of the kinds.
+func (f Fullname) validateKind(kinds ...Kind) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		if f.Kind() == k {
+			return nil
+		}
+		names[i] = string(k)
+	}
+	return fmt.Errorf("%s is no fullname of kind %s", f, strings.Join(names, " or "))
+}
+
+// Kind returns the kind prefix of f, e.g. KindLink for "t3_15bfi0".
+func (f Fullname) Kind() Kind {
+	if i := strings.IndexByte(string(f), '_'); i >= 0 {
+		return Kind(f[:i])
+	}
+	return ""
+}
+
+// ID returns the base36 ID of f, e.g. "15bfi0" for "t3_15bfi0".
+func (f Fullname) ID() string {
+	if i := strings.IndexByte(string(f), '_'); i >= 0 {
+		return string(f[i+1:])
+	}
+	return ""
+}
+
+// Int64 returns the ID of f in base 10. IDs of the same kind
+// increase over time, so they can be used to sort things.
+func (f Fullname) Int64() (int64, error) {
+	return strconv.ParseInt(f.ID(), 36, 64)
+}
+
+func (f Fullname) String() string {
+	return string(f)
+}
+
+// joinFullnames joins fullnames with commas, as reddit expects them
+// in paths and parameters.
+func joinFullnames(names []Fullname) string {
+	s := make([]string, len(names))
+	for i, n := range names {
+		s[i] = string(n)
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,76 @@
+package reddit
+
+import "testing"
+
+func TestParseFullname(t *testing.T) {
+	for _, s := range []string{"t1_c3v7f8u", "t3_15bfi0", "t5_2qh0u", "t2_5sryd"} {
+		if _, err := ParseFullname(s); err != nil {
+			t.Errorf("%s: %s", s, err)
+		}
+	}
+	for _, s := range []string{
+		"", "t3", "t3_", "t30_abc", "t7_abc", "x3_abc", "t3_ABC",
+		"t3_abc-d", "t3_zzzzzzzzzzzzzzzzzzz", "more_abc",
+	} {
+		if _, err := ParseFullname(s); err == nil {
+			t.Errorf("No error for %q", s)
+		}
+	}
+}
+
+func TestFullnameParts(t *testing.T) {
+	f, err := NewFullname(KindLink, "15bfi0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != "t3_15bfi0" {
+		t.Errorf("Fullname was %s instead of t3_15bfi0", f)
+	}
+	if f.Kind() != KindLink || f.ID() != "15bfi0" {
+		t.Errorf("Kind and ID were %s and %s", f.Kind(), f.ID())
+	}
+	n, err := f.Int64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 69397560 {
+		t.Errorf("Int64 was %d instead of 69397560", n)
+	}
+	if g := FullnameFromInt64(KindLink, n); g != f {
+		t.Errorf("FullnameFromInt64 returned %s instead of %s", g, f)
+	}
+	if _, err := NewFullname(KindMore, "abc"); err == nil {
+		t.Error("No error for kind more")
+	}
+}
+
+func TestFullnameFromURL(t *testing.T) {
+	for rawurl, should := range map[string]Fullname{
+		"https://www.reddit.com/r/golang/comments/5572gp/go_18/":         "t3_5572gp",
+		"https://old.reddit.com/r/golang/comments/5572gp/go_18/d87rbux/": "t1_d87rbux",
+		"https://reddit.com/comments/5572gp":                             "t3_5572gp",
+		"/r/golang/comments/5572gp/go_18/d87rbux/?context=3":             "t1_d87rbux",
+		"https://redd.it/5572gp":                                         "t3_5572gp",
+	} {
+		f, err := FullnameFromURL(rawurl)
+		if err != nil {
+			t.Errorf("%s: %s", rawurl, err)
+			continue
+		}
+		if f != should {
+			t.Errorf("%s returned %s instead of %s", rawurl, f, should)
+		}
+	}
+	for _, rawurl := range []string{
+		"https://www.reddit.com/r/golang/",
+		"https://example.com/r/golang/comments/5572gp/",
+		"https://redd.it/",
+		"https://v.redd.it/5572gp",
+		"https://i.redd.it/5572gp",
+		"https://www.reddit.com/r/golang/comments/",
+	} {
+		if f, err := FullnameFromURL(rawurl); err == nil {
+			t.Errorf("No error for %s, returned %s", rawurl, f)
+		}
+	}
+}
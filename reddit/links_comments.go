@@ -0,0 +1,732 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// LinksCommentsService is the API Endpoint for links & comments
+type LinksCommentsService service
+
+// thingsResponse is the shape of reddit's API endpoints
+// that respond with a list of things.
+type thingsResponse struct {
+	JSON struct {
+		Data struct {
+			Things []ListingThing `json:"things"`
+		} `json:"data"`
+	} `json:"json"`
+}
+
+// things returns the decoded things of the response.
+func (r *thingsResponse) things() []Thing {
+	things := make([]Thing, len(r.JSON.Data.Things))
+	for i, t := range r.JSON.Data.Things {
+		things[i] = t.Thing
+	}
+	return things
+}
+
+// maxMoreChildren is the maximum number of children
+// reddit loads with a single request to /api/morechildren.
+const maxMoreChildren = 100
+
+// MoreChildrenOptions are the parameters of MoreChildren.
+type MoreChildrenOptions struct {
+	// Sort order of the comments.
+	Sort CommentSort `url:"sort,omitempty"`
+
+	// Maximum depth of the loaded subtrees.
+	Depth int `url:"depth,omitempty"`
+
+	// Only load the given children, not their replies.
+	LimitChildren bool `url:"limit_children,omitempty"`
+}
+
+// MoreChildren loads the comments hidden behind a More placeholder of
+// the given link. children are the IDs of More.Children, at most 100.
+// The comments are returned as flat list, in which parents appear
+// before their replies, together with further More placeholders.
+// opts may be nil.
+func (s *LinksCommentsService) MoreChildren(link Fullname, children []string, opts *MoreChildrenOptions) ([]Thing, *Response, error) {
+	return s.MoreChildrenContext(context.Background(), link, children, opts)
+}
+
+// MoreChildrenContext is like MoreChildren but uses the given context
+// for the request.
+func (s *LinksCommentsService) MoreChildrenContext(ctx context.Context, link Fullname, children []string, opts *MoreChildrenOptions) ([]Thing, *Response, error) {
+	if err := link.validateKind(KindLink); err != nil {
+		return nil, nil, err
+	}
+	if len(children) > maxMoreChildren {
+		return nil, nil, fmt.Errorf("Can't load more than %d children at once", maxMoreChildren)
+	}
+	path, err := addOptions("/api/morechildren", &struct {
+		*MoreChildrenOptions
+		APIType  string   `url:"api_type"`
+		LinkID   Fullname `url:"link_id"`
+		Children []string `url:"children"`
+	}{opts, "json", link, children})
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var tr thingsResponse
+	resp, err := s.client.DoContext(ctx, r, &tr)
+	if err != nil {
+		return nil, resp, err
+	}
+	return tr.things(), resp, nil
+}
+
+// ExpandMore loads all comments hidden behind More placeholders of lc
+// through MoreChildren, in batches of 100, and splices them into the
+// comment tree under their parents. Placeholders of deep threads
+// ("continue this thread"), which have no children, are resolved by
+// loading the comments of their parent. Such placeholders are dropped
+// if their parent is the link, or if their parent has been resolved
+// before, which happens when the reloaded thread is cut off by
+// opts.Depth. Children that have been requested before are dropped
+// as well, in case reddit returns them again. It repeats until the
+// tree has no placeholders left. lc must include its link.
+// opts may be nil.
+func (s *LinksCommentsService) ExpandMore(lc *LinkComments, opts *MoreChildrenOptions) (*Response, error) {
+	return s.ExpandMoreContext(context.Background(), lc, opts)
+}
+
+// ExpandMoreContext is like ExpandMore but uses the given context
+// for requests.
+func (s *LinksCommentsService) ExpandMoreContext(ctx context.Context, lc *LinkComments, opts *MoreChildrenOptions) (*Response, error) {
+	if lc == nil || lc.Link == nil {
+		return nil, errors.New("Can't expand the comments of an unknown link")
+	}
+	link := lc.Link.Name
+	var resp *Response
+	resolved := make(map[Fullname]bool)
+	requested := make(map[string]bool)
+	for {
+		var (
+			ids     []string
+			threads []*Replies
+			parents = map[Fullname]*Replies{link: &lc.Comments}
+		)
+		walkReplies(&lc.Comments, func(c *Comment) {
+			parents[c.Name] = &c.Replies
+		}, func(r *Replies) {
+			if len(r.More.Children) == 0 {
+				threads = append(threads, r)
+				return
+			}
+			for _, id := range r.More.Children {
+				if !requested[id] {
+					requested[id] = true
+					ids = append(ids, id)
+				}
+			}
+			r.More = nil
+		})
+		if len(ids) == 0 && len(threads) == 0 {
+			return resp, nil
+		}
+		for len(ids) > 0 {
+			n := len(ids)
+			if n > maxMoreChildren {
+				n = maxMoreChildren
+			}
+			var (
+				things []Thing
+				err    error
+			)
+			things, resp, err = s.MoreChildrenContext(ctx, link, ids[:n], opts)
+			if err != nil {
+				return resp, err
+			}
+			ids = ids[n:]
+			spliceThings(parents, things)
+		}
+		for _, r := range threads {
+			parent := r.More.ParentID
+			if parent.Kind() != KindComment || resolved[parent] {
+				r.More = nil
+				continue
+			}
+			resolved[parent] = true
+			var err error
+			if resp, err = s.continueThread(ctx, link, r, opts); err != nil {
+				return resp, err
+			}
+		}
+	}
+}
+
+// continueThread replaces the "continue this thread" placeholder of r
+// with the replies of its parent.
+func (s *LinksCommentsService) continueThread(ctx context.Context, link Fullname, r *Replies, opts *MoreChildrenOptions) (*Response, error) {
+	parent := r.More.ParentID
+	r.More = nil
+	co := &CommentsOptions{Comment: parent}
+	if opts != nil {
+		co.Sort, co.Depth = opts.Sort, opts.Depth
+	}
+	thread, resp, err := (*ListingsService)(s).CommentsContext(ctx, link, co)
+	if err != nil {
+		return resp, err
+	}
+	for _, c := range thread.Comments.Comments {
+		if c.Name == parent {
+			r.Comments = append(r.Comments, c.Replies.Comments...)
+			r.More = c.Replies.More
+		}
+	}
+	return resp, nil
+}
+
+// spliceThings adds the comments and placeholders loaded by
+// MoreChildren to the replies of their parents. Things whose
+// parent is unknown are dropped.
+func spliceThings(parents map[Fullname]*Replies, things []Thing) {
+	for _, t := range things {
+		if c, ok := t.(*Comment); ok {
+			parents[c.Name] = &c.Replies
+		}
+	}
+	for _, t := range things {
+		switch v := t.(type) {
+		case *Comment:
+			if p, ok := parents[v.ParentID]; ok {
+				p.Comments = append(p.Comments, v)
+			}
+		case *More:
+			p, ok := parents[v.ParentID]
+			if !ok {
+				continue
+			}
+			if p.More == nil {
+				p.More = v
+				continue
+			}
+			p.More.Children = append(p.More.Children, v.Children...)
+			p.More.Count += v.Count
+		}
+	}
+}
+
+// walkReplies calls comment for every comment of the tree r
+// and more for every Replies holding a More placeholder.
+func walkReplies(r *Replies, comment func(*Comment), more func(*Replies)) {
+	for _, c := range r.Comments {
+		comment(c)
+		walkReplies(&c.Replies, comment, more)
+	}
+	if r.More != nil {
+		more(r)
+	}
+}
+
+// SubmitKind is the kind of a submission.
+type SubmitKind string
+
+const (
+	SubmitLink     SubmitKind = "link"
+	SubmitSelf     SubmitKind = "self"
+	SubmitImage    SubmitKind = "image"
+	SubmitVideo    SubmitKind = "video"
+	SubmitVideoGIF SubmitKind = "videogif"
+)
+
+// SubmitRequest describes a new link to submit.
+type SubmitRequest struct {
+	Kind SubmitKind `url:"kind"`
+
+	// Name of the subreddit to submit to, without the /r/ prefix
+	Subreddit string `url:"sr"`
+
+	// Title of the submission, up to 300 characters
+	Title string `url:"title"`
+
+	// URL of a link. For image and video submissions, the URL
+	// of media that has already been uploaded to reddit.
+	URL string `url:"url,omitempty"`
+
+	// Raw markdown text of a self post
+	Text string `url:"text,omitempty"`
+
+	// URL of the poster image of a video submission
+	VideoPosterURL string `url:"video_poster_url,omitempty"`
+
+	// ID of a flair template, and the flair text if the
+	// template allows to edit it
+	FlairID   string `url:"flair_id,omitempty"`
+	FlairText string `url:"flair_text,omitempty"`
+
+	// Mark the submission as NSFW or as spoiler
+	NSFW    bool `url:"nsfw,omitempty"`
+	Spoiler bool `url:"spoiler,omitempty"`
+
+	// Send replies to the inbox of the author.
+	// Reddit defaults to true.
+	SendReplies *bool `url:"sendreplies,omitempty"`
+
+	// Submit a link even if it has been submitted to the
+	// subreddit before. Otherwise reddit fails with ALREADY_SUB.
+	Resubmit bool `url:"resubmit,omitempty"`
+
+	// ID of a collection to add the submission to
+	CollectionID string `url:"collection_id,omitempty"`
+}
+
+// Submitted identifies a newly submitted link.
+type Submitted struct {
+	// ID of the new link, e.g. "8xwlg"
+	ID string `json:"id"`
+
+	// Fullname of the new link, e.g. "t3_8xwlg"
+	Name Fullname `json:"name"`
+
+	// Full URL of the new link's comment page
+	URL string `json:"url"`
+}
+
+// Submit submits a new link, self post, image or video. Failures are
+// returned as JSONErrors, e.g. ErrAlreadySub or ErrRateLimit, whose
+// RetryAfter tells how long to wait before submitting again.
+func (s *LinksCommentsService) Submit(sr *SubmitRequest) (*Submitted, *Response, error) {
+	return s.SubmitContext(context.Background(), sr)
+}
+
+// SubmitContext is like Submit but uses the given context for the request.
+func (s *LinksCommentsService) SubmitContext(ctx context.Context, sr *SubmitRequest) (*Submitted, *Response, error) {
+	if sr == nil {
+		return nil, nil, errors.New("Can't submit a nil SubmitRequest")
+	}
+	var out struct {
+		JSON struct {
+			Data Submitted `json:"data"`
+		} `json:"json"`
+	}
+	resp, err := s.client.postForm(ctx, "/api/submit", &struct {
+		*SubmitRequest
+		APIType string `url:"api_type"`
+	}{sr, "json"}, &out)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &out.JSON.Data, resp, nil
+}
+
+// firstComment returns the first comment of things, or nil.
+func firstComment(things []Thing) *Comment {
+	for _, t := range things {
+		if c, ok := t.(*Comment); ok {
+			return c
+		}
+	}
+	return nil
+}
+
+// Comment replies with the markdown text to parent, the fullname of a
+// link, comment or message, and returns the new comment. Replies to
+// messages are messages themselves, in which case the returned Comment
+// is nil; use ReplyMessage to get them.
+func (s *LinksCommentsService) Comment(parent Fullname, text string) (*Comment, *Response, error) {
+	return s.CommentContext(context.Background(), parent, text)
+}
+
+// CommentContext is like Comment but uses the given context for the request.
+func (s *LinksCommentsService) CommentContext(ctx context.Context, parent Fullname, text string) (*Comment, *Response, error) {
+	if err := parent.validateKind(KindLink, KindComment, KindMessage); err != nil {
+		return nil, nil, err
+	}
+	things, resp, err := s.comment(ctx, parent, text)
+	if err != nil {
+		return nil, resp, err
+	}
+	return firstComment(things), resp, nil
+}
+
+// ReplyMessage replies with the markdown text to the message parent
+// and returns the new message.
+func (s *LinksCommentsService) ReplyMessage(parent Fullname, text string) (*Message, *Response, error) {
+	return s.ReplyMessageContext(context.Background(), parent, text)
+}
+
+// ReplyMessageContext is like ReplyMessage but uses the given context
+// for the request.
+func (s *LinksCommentsService) ReplyMessageContext(ctx context.Context, parent Fullname, text string) (*Message, *Response, error) {
+	if err := parent.validateKind(KindMessage); err != nil {
+		return nil, nil, err
+	}
+	things, resp, err := s.comment(ctx, parent, text)
+	if err != nil {
+		return nil, resp, err
+	}
+	for _, t := range things {
+		if m, ok := t.(*Message); ok {
+			return m, resp, nil
+		}
+	}
+	return nil, resp, nil
+}
+
+// comment posts a reply to parent and returns the created things.
+func (s *LinksCommentsService) comment(ctx context.Context, parent Fullname, text string) ([]Thing, *Response, error) {
+	var tr thingsResponse
+	resp, err := s.client.postForm(ctx, "/api/comment", url.Values{
+		"api_type": {"json"},
+		"thing_id": {string(parent)},
+		"text":     {text},
+	}, &tr)
+	if err != nil {
+		return nil, resp, err
+	}
+	return tr.things(), resp, nil
+}
+
+// EditUserText replaces the markdown text of a comment or self post
+// and returns the updated comment. For self posts, reddit responds with
+// the link, in which case the returned Comment is nil.
+func (s *LinksCommentsService) EditUserText(thing Fullname, text string) (*Comment, *Response, error) {
+	return s.EditUserTextContext(context.Background(), thing, text)
+}
+
+// EditUserTextContext is like EditUserText but uses the given context
+// for the request.
+func (s *LinksCommentsService) EditUserTextContext(ctx context.Context, thing Fullname, text string) (*Comment, *Response, error) {
+	if err := thing.validateKind(KindLink, KindComment); err != nil {
+		return nil, nil, err
+	}
+	var tr thingsResponse
+	resp, err := s.client.postForm(ctx, "/api/editusertext", url.Values{
+		"api_type": {"json"},
+		"thing_id": {string(thing)},
+		"text":     {text},
+	}, &tr)
+	if err != nil {
+		return nil, resp, err
+	}
+	return firstComment(tr.things()), resp, nil
+}
+
+// Delete deletes a link or comment of the user.
+func (s *LinksCommentsService) Delete(thing Fullname) (*Response, error) {
+	return s.DeleteContext(context.Background(), thing)
+}
+
+// DeleteContext is like Delete but uses the given context for the request.
+func (s *LinksCommentsService) DeleteContext(ctx context.Context, thing Fullname) (*Response, error) {
+	if err := thing.validateKind(KindLink, KindComment); err != nil {
+		return nil, err
+	}
+	return s.client.postForm(ctx, "/api/del", url.Values{"id": {string(thing)}}, nil)
+}
+
+// postThing posts form with the fullname of thing as id to path,
+// after checking that thing is of one of the kinds.
+func (s *LinksCommentsService) postThing(ctx context.Context, path string, thing Fullname, kinds []Kind, form url.Values) (*Response, error) {
+	if err := thing.validateKind(kinds...); err != nil {
+		return nil, err
+	}
+	if form == nil {
+		form = url.Values{}
+	}
+	form.Set("api_type", "json")
+	form.Set("id", string(thing))
+	return s.client.postForm(ctx, path, form, nil)
+}
+
+// VoteDirection is the direction of a vote.
+type VoteDirection int
+
+const (
+	Downvote VoteDirection = -1
+	Unvote   VoteDirection = 0
+	Upvote   VoteDirection = 1
+)
+
+// Vote votes on a link or comment. Unvote removes the user's vote.
+func (s *LinksCommentsService) Vote(thing Fullname, dir VoteDirection) (*Response, error) {
+	return s.VoteContext(context.Background(), thing, dir)
+}
+
+// VoteContext is like Vote but uses the given context for the request.
+func (s *LinksCommentsService) VoteContext(ctx context.Context, thing Fullname, dir VoteDirection) (*Response, error) {
+	if dir < Downvote || dir > Upvote {
+		return nil, fmt.Errorf("Invalid vote direction %d", dir)
+	}
+	return s.postThing(ctx, "/api/vote", thing, []Kind{KindLink, KindComment},
+		url.Values{"dir": {strconv.Itoa(int(dir))}})
+}
+
+// Save saves a link or comment of the user, optionally in a category.
+// Categories are only available to reddit gold members.
+func (s *LinksCommentsService) Save(thing Fullname, category string) (*Response, error) {
+	return s.SaveContext(context.Background(), thing, category)
+}
+
+// SaveContext is like Save but uses the given context for the request.
+func (s *LinksCommentsService) SaveContext(ctx context.Context, thing Fullname, category string) (*Response, error) {
+	form := url.Values{}
+	if category != "" {
+		form.Set("category", category)
+	}
+	return s.postThing(ctx, "/api/save", thing, []Kind{KindLink, KindComment}, form)
+}
+
+// Unsave removes a link or comment from the saved things of the user.
+func (s *LinksCommentsService) Unsave(thing Fullname) (*Response, error) {
+	return s.UnsaveContext(context.Background(), thing)
+}
+
+// UnsaveContext is like Unsave but uses the given context for the request.
+func (s *LinksCommentsService) UnsaveContext(ctx context.Context, thing Fullname) (*Response, error) {
+	return s.postThing(ctx, "/api/unsave", thing, []Kind{KindLink, KindComment}, nil)
+}
+
+// Hide hides links from the listings of the user.
+func (s *LinksCommentsService) Hide(links ...Fullname) (*Response, error) {
+	return s.HideContext(context.Background(), links...)
+}
+
+// HideContext is like Hide but uses the given context for the request.
+func (s *LinksCommentsService) HideContext(ctx context.Context, links ...Fullname) (*Response, error) {
+	return s.setHidden(ctx, "/api/hide", links)
+}
+
+// Unhide shows previously hidden links again.
+func (s *LinksCommentsService) Unhide(links ...Fullname) (*Response, error) {
+	return s.UnhideContext(context.Background(), links...)
+}
+
+// UnhideContext is like Unhide but uses the given context for the request.
+func (s *LinksCommentsService) UnhideContext(ctx context.Context, links ...Fullname) (*Response, error) {
+	return s.setHidden(ctx, "/api/unhide", links)
+}
+
+func (s *LinksCommentsService) setHidden(ctx context.Context, path string, links []Fullname) (*Response, error) {
+	if len(links) == 0 {
+		return nil, fmt.Errorf("No links given")
+	}
+	for _, l := range links {
+		if err := l.validateKind(KindLink); err != nil {
+			return nil, err
+		}
+	}
+	return s.client.postForm(ctx, path, url.Values{
+		"api_type": {"json"},
+		"id":       {joinFullnames(links)},
+	}, nil)
+}
+
+// SavedCategories returns the categories the user
+// has saved things in.
+func (s *LinksCommentsService) SavedCategories() ([]string, *Response, error) {
+	return s.SavedCategoriesContext(context.Background())
+}
+
+// SavedCategoriesContext is like SavedCategories but uses the given
+// context for the request.
+func (s *LinksCommentsService) SavedCategoriesContext(ctx context.Context) ([]string, *Response, error) {
+	r, err := s.client.NewRequest("GET", "/api/saved_categories", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var out struct {
+		Categories []struct {
+			Category string `json:"category"`
+		} `json:"categories"`
+	}
+	resp, err := s.client.DoContext(ctx, r, &out)
+	if err != nil {
+		return nil, resp, err
+	}
+	categories := make([]string, len(out.Categories))
+	for i, c := range out.Categories {
+		categories[i] = c.Category
+	}
+	return categories, resp, nil
+}
+
+// ReportRequest describes a report of a thing to the moderators
+// of its subreddit or to the reddit admins.
+type ReportRequest struct {
+	// Fullname of the reported link, comment or message
+	Thing Fullname `url:"thing_id"`
+
+	// Free text reason
+	Reason string `url:"reason,omitempty"`
+
+	// Short name of the violated subreddit rule
+	RuleReason string `url:"rule_reason,omitempty"`
+
+	// Reason of a report to the reddit admins
+	SiteReason string `url:"site_reason,omitempty"`
+
+	// Free text reason if none of the rules apply
+	OtherReason string `url:"other_reason,omitempty"`
+}
+
+// Report reports a link, comment or message.
+func (s *LinksCommentsService) Report(rr *ReportRequest) (*Response, error) {
+	return s.ReportContext(context.Background(), rr)
+}
+
+// ReportContext is like Report but uses the given context for the request.
+func (s *LinksCommentsService) ReportContext(ctx context.Context, rr *ReportRequest) (*Response, error) {
+	if rr == nil {
+		return nil, errors.New("Can't report a nil ReportRequest")
+	}
+	if err := rr.Thing.validateKind(KindLink, KindComment, KindMessage); err != nil {
+		return nil, err
+	}
+	return s.client.postForm(ctx, "/api/report", &struct {
+		*ReportRequest
+		APIType string `url:"api_type"`
+	}{rr, "json"}, nil)
+}
+
+// Lock locks a link or comment, so no new comments can be
+// posted in reply to it. Requires moderator permissions.
+func (s *LinksCommentsService) Lock(thing Fullname) (*Response, error) {
+	return s.LockContext(context.Background(), thing)
+}
+
+// LockContext is like Lock but uses the given context for the request.
+func (s *LinksCommentsService) LockContext(ctx context.Context, thing Fullname) (*Response, error) {
+	return s.postThing(ctx, "/api/lock", thing, []Kind{KindLink, KindComment}, nil)
+}
+
+// Unlock unlocks a link or comment.
+func (s *LinksCommentsService) Unlock(thing Fullname) (*Response, error) {
+	return s.UnlockContext(context.Background(), thing)
+}
+
+// UnlockContext is like Unlock but uses the given context for the request.
+func (s *LinksCommentsService) UnlockContext(ctx context.Context, thing Fullname) (*Response, error) {
+	return s.postThing(ctx, "/api/unlock", thing, []Kind{KindLink, KindComment}, nil)
+}
+
+// MarkNSFW marks a link as NSFW.
+func (s *LinksCommentsService) MarkNSFW(link Fullname) (*Response, error) {
+	return s.MarkNSFWContext(context.Background(), link)
+}
+
+// MarkNSFWContext is like MarkNSFW but uses the given context for the request.
+func (s *LinksCommentsService) MarkNSFWContext(ctx context.Context, link Fullname) (*Response, error) {
+	return s.postThing(ctx, "/api/marknsfw", link, []Kind{KindLink}, nil)
+}
+
+// UnmarkNSFW removes the NSFW mark of a link.
+func (s *LinksCommentsService) UnmarkNSFW(link Fullname) (*Response, error) {
+	return s.UnmarkNSFWContext(context.Background(), link)
+}
+
+// UnmarkNSFWContext is like UnmarkNSFW but uses the given context
+// for the request.
+func (s *LinksCommentsService) UnmarkNSFWContext(ctx context.Context, link Fullname) (*Response, error) {
+	return s.postThing(ctx, "/api/unmarknsfw", link, []Kind{KindLink}, nil)
+}
+
+// Spoiler marks a link as spoiler.
+func (s *LinksCommentsService) Spoiler(link Fullname) (*Response, error) {
+	return s.SpoilerContext(context.Background(), link)
+}
+
+// SpoilerContext is like Spoiler but uses the given context for the request.
+func (s *LinksCommentsService) SpoilerContext(ctx context.Context, link Fullname) (*Response, error) {
+	return s.postThing(ctx, "/api/spoiler", link, []Kind{KindLink}, nil)
+}
+
+// Unspoiler removes the spoiler mark of a link.
+func (s *LinksCommentsService) Unspoiler(link Fullname) (*Response, error) {
+	return s.UnspoilerContext(context.Background(), link)
+}
+
+// UnspoilerContext is like Unspoiler but uses the given context
+// for the request.
+func (s *LinksCommentsService) UnspoilerContext(ctx context.Context, link Fullname) (*Response, error) {
+	return s.postThing(ctx, "/api/unspoiler", link, []Kind{KindLink}, nil)
+}
+
+// SendReplies enables or disables sending replies to a link
+// or comment of the user to the user's inbox.
+func (s *LinksCommentsService) SendReplies(thing Fullname, state bool) (*Response, error) {
+	return s.SendRepliesContext(context.Background(), thing, state)
+}
+
+// SendRepliesContext is like SendReplies but uses the given context
+// for the request.
+func (s *LinksCommentsService) SendRepliesContext(ctx context.Context, thing Fullname, state bool) (*Response, error) {
+	return s.postThing(ctx, "/api/sendreplies", thing, []Kind{KindLink, KindComment},
+		url.Values{"state": {strconv.FormatBool(state)}})
+}
+
+// SetContestMode enables or disables the contest mode of a link,
+// which shows its comments in random order with hidden scores.
+// Requires moderator permissions.
+func (s *LinksCommentsService) SetContestMode(link Fullname, state bool) (*Response, error) {
+	return s.SetContestModeContext(context.Background(), link, state)
+}
+
+// SetContestModeContext is like SetContestMode but uses the given
+// context for the request.
+func (s *LinksCommentsService) SetContestModeContext(ctx context.Context, link Fullname, state bool) (*Response, error) {
+	return s.postThing(ctx, "/api/set_contest_mode", link, []Kind{KindLink},
+		url.Values{"state": {strconv.FormatBool(state)}})
+}
+
+// SetSuggestedSort sets the sort order suggested for the comments
+// of a link. An empty sort removes the suggestion.
+// Requires moderator permissions.
+func (s *LinksCommentsService) SetSuggestedSort(link Fullname, sort CommentSort) (*Response, error) {
+	return s.SetSuggestedSortContext(context.Background(), link, sort)
+}
+
+// SetSuggestedSortContext is like SetSuggestedSort but uses the given
+// context for the request.
+func (s *LinksCommentsService) SetSuggestedSortContext(ctx context.Context, link Fullname, sort CommentSort) (*Response, error) {
+	if sort == "" {
+		sort = "blank"
+	}
+	return s.postThing(ctx, "/api/set_suggested_sort", link, []Kind{KindLink},
+		url.Values{"sort": {string(sort)}})
+}
+
+// StickyRequest describes how a link is stickied.
+type StickyRequest struct {
+	// Sticky or unsticky the link
+	State bool `url:"state"`
+
+	// Slot of the sticky, 1 to 4. Reddit replaces the bottom
+	// sticky if zero.
+	Num int `url:"num,omitempty"`
+
+	// Pin the link to the profile of the user instead
+	// of stickying it in its subreddit.
+	ToProfile bool `url:"to_profile,omitempty"`
+}
+
+// SetSubredditSticky stickies or unstickies a link in its subreddit,
+// or pins it to the profile of the user. Stickying in a subreddit
+// requires moderator permissions.
+func (s *LinksCommentsService) SetSubredditSticky(link Fullname, sr *StickyRequest) (*Response, error) {
+	return s.SetSubredditStickyContext(context.Background(), link, sr)
+}
+
+// SetSubredditStickyContext is like SetSubredditSticky but uses the
+// given context for the request.
+func (s *LinksCommentsService) SetSubredditStickyContext(ctx context.Context, link Fullname, sr *StickyRequest) (*Response, error) {
+	if sr == nil {
+		return nil, errors.New("Can't apply a nil StickyRequest")
+	}
+	form, err := encodeValues(sr)
+	if err != nil {
+		return nil, err
+	}
+	return s.postThing(ctx, "/api/set_subreddit_sticky", link, []Kind{KindLink}, form)
+}
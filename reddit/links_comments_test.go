@@ -0,0 +1,448 @@
+package reddit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testCommentJSON(id, parent string) string {
+	return fmt.Sprintf(`{"kind": "t1", "data": {"id": "%s", "name": "t1_%s", "parent_id": "%s", "replies": ""}}`,
+		id, id, parent)
+}
+
+func TestLinksCommentsMoreChildren(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/morechildren" || q.Get("api_type") != "json" ||
+			q.Get("link_id") != "t3_l" || q.Get("children") != "a,b" || q.Get("sort") != "top" {
+			t.Errorf("Requested %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		fmt.Fprintf(w, `{"json": {"errors": [], "data": {"things": [%s, %s]}}}`,
+			testCommentJSON("a", "t1_p"), testCommentJSON("b", "t1_a"))
+	})
+	defer ts.Close()
+	things, _, err := client.LinksComments.MoreChildren("t3_l", []string{"a", "b"},
+		&MoreChildrenOptions{Sort: CommentSortTop})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(things) != 2 || things[1].(*Comment).ParentID != "t1_a" {
+		t.Errorf("Returned %#v", things)
+	}
+}
+
+func TestLinksCommentsExpandMore(t *testing.T) {
+	var batches []int
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/morechildren":
+			ids := strings.Split(r.URL.Query().Get("children"), ",")
+			batches = append(batches, len(ids))
+			var things []string
+			for _, id := range ids {
+				parent := "t3_l"
+				if id == "m0" {
+					parent = "t1_c1"
+				}
+				things = append(things, testCommentJSON(id, parent))
+			}
+			if ids[0] == "m0" {
+				// A reply to a loaded child and a deep thread placeholder.
+				things = append(things, testCommentJSON("m0r", "t1_m0"),
+					`{"kind": "more", "data": {"id": "_", "parent_id": "t1_m0r", "count": 0, "children": []}}`)
+			}
+			fmt.Fprintf(w, `{"json": {"errors": [], "data": {"things": [%s]}}}`,
+				strings.Join(things, ","))
+		case "/comments/l":
+			if c := r.URL.Query().Get("comment"); c != "m0r" {
+				t.Errorf("Focus comment was '%s' instead of 'm0r'", c)
+			}
+			fmt.Fprintf(w, `[{"kind": "Listing", "data": {"children": [{"kind": "t3", "data": {"name": "t3_l"}}]}},
+				{"kind": "Listing", "data": {"children": [{"kind": "t1", "data": {
+					"id": "m0r", "name": "t1_m0r", "parent_id": "t1_m0",
+					"replies": {"kind": "Listing", "data": {"children": [%s]}}
+				}}]}}]`, testCommentJSON("deep", "t1_m0r"))
+		default:
+			t.Errorf("Requested %s", r.URL.Path)
+		}
+	})
+	defer ts.Close()
+
+	more := &More{ParentID: "t3_l"}
+	for i := 0; i < 105; i++ {
+		more.Children = append(more.Children, fmt.Sprintf("m%d", i))
+	}
+	lc := &LinkComments{
+		Link: &Link{Name: "t3_l"},
+		Comments: Replies{
+			Comments: []*Comment{{Name: "t1_c1", ParentID: "t3_l"}},
+			More:     more,
+		},
+	}
+	if _, err := client.LinksComments.ExpandMore(lc, nil); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(batches) != "[100 5]" {
+		t.Errorf("Batches were %v instead of [100 5]", batches)
+	}
+	if n := len(lc.Comments.Comments); n != 105 {
+		t.Errorf("Root has %d instead of 105 comments", n)
+	}
+	if lc.Comments.More != nil {
+		t.Errorf("Root still has a More placeholder: %#v", lc.Comments.More)
+	}
+	c1 := lc.Comments.Comments[0]
+	if len(c1.Replies.Comments) != 1 || c1.Replies.Comments[0].Name != "t1_m0" {
+		t.Fatalf("Replies of c1 were %#v", c1.Replies.Comments)
+	}
+	m0r := c1.Replies.Comments[0].Replies.Comments[0]
+	if m0r.Replies.More != nil || len(m0r.Replies.Comments) != 1 ||
+		m0r.Replies.Comments[0].Name != "t1_deep" {
+		t.Errorf("Deep thread was not continued: %#v", m0r.Replies)
+	}
+}
+
+func TestLinksCommentsExpandMoreStubs(t *testing.T) {
+	var requests []string
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/morechildren":
+			// A reply to a comment that isn't in the tree.
+			fmt.Fprintf(w, `{"json": {"errors": [], "data": {"things": [%s, %s,
+				{"kind": "more", "data": {"id": "x", "parent_id": "t1_unknown", "count": 1, "children": ["x"]}}]}}}`,
+				testCommentJSON("orphan", "t1_unknown"), testCommentJSON("orphanr", "t1_orphan"))
+		case "/comments/l":
+			// The reloaded thread is cut off by the depth
+			// again, with the same placeholder.
+			fmt.Fprint(w, `[{"kind": "Listing", "data": {"children": [{"kind": "t3", "data": {"name": "t3_l"}}]}},
+				{"kind": "Listing", "data": {"children": [{"kind": "t1", "data": {
+					"id": "c1", "name": "t1_c1", "parent_id": "t3_l",
+					"replies": {"kind": "Listing", "data": {"children": [
+						{"kind": "more", "data": {"id": "_", "parent_id": "t1_c1", "count": 0, "children": []}}
+					]}}
+				}}]}}]`)
+		default:
+			t.Errorf("Requested %s", r.URL.Path)
+		}
+	})
+	defer ts.Close()
+	c1 := &Comment{Name: "t1_c1", ParentID: "t3_l", Replies: Replies{
+		More: &More{ParentID: "t1_c1"},
+	}}
+	c2 := &Comment{Name: "t1_c2", ParentID: "t3_l", Replies: Replies{
+		More: &More{ParentID: "t1_c2", Count: 1, Children: []string{"orphan"}},
+	}}
+	lc := &LinkComments{
+		Link: &Link{Name: "t3_l"},
+		Comments: Replies{
+			Comments: []*Comment{c1, c2},
+			More:     &More{ParentID: "t3_l"},
+		},
+	}
+	if _, err := client.LinksComments.ExpandMore(lc, &MoreChildrenOptions{Depth: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if s := strings.Join(requests, ","); s != "/api/morechildren,/comments/l" {
+		t.Errorf("Requested %s", s)
+	}
+	if lc.Comments.More != nil || c1.Replies.More != nil || c2.Replies.More != nil {
+		t.Errorf("Placeholders were left: %#v, %#v, %#v", lc.Comments.More, c1.Replies.More, c2.Replies.More)
+	}
+	if len(lc.Comments.Comments) != 2 || len(c1.Replies.Comments) != 0 || len(c2.Replies.Comments) != 0 {
+		t.Errorf("Orphaned comments were added: %#v", lc.Comments)
+	}
+}
+
+func TestLinksCommentsExpandMoreWithoutLink(t *testing.T) {
+	lc := &LinkComments{Comments: Replies{More: &More{Children: []string{"a"}}}}
+	if _, err := NewClient(nil).LinksComments.ExpandMore(lc, nil); err == nil {
+		t.Error("No error returned")
+	}
+}
+
+func TestLinksCommentsExpandMoreRepeated(t *testing.T) {
+	var requests int
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// The loaded child comes back together with
+		// a placeholder of itself.
+		fmt.Fprintf(w, `{"json": {"errors": [], "data": {"things": [%s,
+			{"kind": "more", "data": {"id": "a", "parent_id": "t3_l", "count": 1, "children": ["a"]}}]}}}`,
+			testCommentJSON("a", "t3_l"))
+	})
+	defer ts.Close()
+	lc := &LinkComments{
+		Link:     &Link{Name: "t3_l"},
+		Comments: Replies{More: &More{ParentID: "t3_l", Children: []string{"a"}}},
+	}
+	if _, err := client.LinksComments.ExpandMore(lc, nil); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("Made %d instead of 1 request", requests)
+	}
+	if len(lc.Comments.Comments) != 1 || lc.Comments.More != nil {
+		t.Errorf("Tree was %#v", lc.Comments)
+	}
+}
+
+func TestLinksCommentsSubmit(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/submit" {
+			t.Errorf("Requested %s %s", r.Method, r.URL.Path)
+		}
+		r.ParseForm()
+		should := url.Values{
+			"api_type":    {"json"},
+			"kind":        {"self"},
+			"sr":          {"golang"},
+			"title":       {"Hello"},
+			"text":        {"World"},
+			"nsfw":        {"true"},
+			"sendreplies": {"false"},
+		}
+		if f := r.PostForm.Encode(); f != should.Encode() {
+			t.Errorf("Form was %s instead of %s", f, should.Encode())
+		}
+		fmt.Fprint(w, `{"json": {"errors": [], "data": {
+			"url": "https://www.reddit.com/r/golang/comments/abc/hello/",
+			"id": "abc",
+			"name": "t3_abc"
+		}}}`)
+	})
+	defer ts.Close()
+	sendReplies := false
+	sub, _, err := client.LinksComments.Submit(&SubmitRequest{
+		Kind:        SubmitSelf,
+		Subreddit:   "golang",
+		Title:       "Hello",
+		Text:        "World",
+		NSFW:        true,
+		SendReplies: &sendReplies,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.Name != "t3_abc" || sub.URL != "https://www.reddit.com/r/golang/comments/abc/hello/" {
+		t.Errorf("Submitted was %#v", sub)
+	}
+}
+
+func TestLinksCommentsSubmitErrors(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"json": {"ratelimit": 451.5, "errors": [
+			["RATELIMIT", "you are doing that too much. try again in 7 minutes.", "ratelimit"],
+			["ALREADY_SUB", "that link has already been submitted", "url"]
+		]}}`)
+	})
+	defer ts.Close()
+	_, _, err := client.LinksComments.Submit(&SubmitRequest{Kind: SubmitLink, URL: "https://golang.org"})
+	if !errors.Is(err, ErrAlreadySub) {
+		t.Errorf("Returned '%v' instead of ErrAlreadySub", err)
+	}
+	var jsonErr *JSONError
+	if !errors.As(err, &jsonErr) || jsonErr.RetryAfter != 451500*time.Millisecond {
+		t.Errorf("RATELIMIT error was %#v", jsonErr)
+	}
+}
+
+func TestLinksCommentsSubmitNil(t *testing.T) {
+	if _, _, err := NewClient(nil).LinksComments.Submit(nil); err == nil {
+		t.Error("No error returned")
+	}
+}
+
+func TestLinksCommentsComment(t *testing.T) {
+	for _, path := range []string{"/api/comment", "/api/editusertext"} {
+		client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != path {
+				t.Errorf("Path was '%s' instead of '%s'", r.URL.Path, path)
+			}
+			if r.PostFormValue("api_type") != "json" || r.PostFormValue("thing_id") != "t1_p" ||
+				r.PostFormValue("text") != "**hi**" {
+				t.Errorf("Form was %v", r.PostForm)
+			}
+			fmt.Fprint(w, `{"json": {"errors": [], "data": {"things": [{"kind": "t1", "data": {
+				"id": "c", "name": "t1_c", "parent_id": "t1_p", "body": "**hi**", "replies": ""
+			}}]}}}`)
+		})
+		call := client.LinksComments.Comment
+		if path == "/api/editusertext" {
+			call = client.LinksComments.EditUserText
+		}
+		c, _, err := call("t1_p", "**hi**")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Name != "t1_c" || c.Body != "**hi**" {
+			t.Errorf("Comment was %#v", c)
+		}
+		ts.Close()
+	}
+}
+
+func TestLinksCommentsReplyMessage(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/comment" || r.PostFormValue("thing_id") != "t4_p" {
+			t.Errorf("Requested %s with %v", r.URL.Path, r.PostForm)
+		}
+		fmt.Fprint(w, `{"json": {"errors": [], "data": {"things": [{"kind": "t4", "data": {
+			"id": "m", "name": "t4_m", "body": "hi"
+		}}]}}}`)
+	})
+	defer ts.Close()
+	m, _, err := client.LinksComments.ReplyMessage("t4_p", "hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m == nil || m.Name != "t4_m" || m.Body != "hi" {
+		t.Errorf("Message was %#v", m)
+	}
+	if _, _, err := client.LinksComments.ReplyMessage("t1_c", "hi"); err == nil {
+		t.Error("No error for replying to a comment")
+	}
+}
+
+func TestLinksCommentsCommentInvalidParent(t *testing.T) {
+	client := NewClient(nil)
+	if _, _, err := client.LinksComments.Comment("t5_sub", "hi"); err == nil {
+		t.Error("No error for replying to a subreddit")
+	}
+	if _, _, err := client.LinksComments.EditUserText("t4_msg", "hi"); err == nil {
+		t.Error("No error for editing a message")
+	}
+	if _, err := client.LinksComments.Delete("t3_"); err == nil {
+		t.Error("No error for an invalid fullname")
+	}
+}
+
+func TestLinksCommentsDelete(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/del" || r.PostFormValue("id") != "t3_abc" {
+			t.Errorf("Requested %s with %v", r.URL.Path, r.PostForm)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+	defer ts.Close()
+	if _, err := client.LinksComments.Delete("t3_abc"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLinksCommentsThingActions(t *testing.T) {
+	var (
+		path string
+		form url.Values
+	)
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		path, form = r.URL.Path, r.PostForm
+		fmt.Fprint(w, `{}`)
+	})
+	defer ts.Close()
+	lc := client.LinksComments
+	for i, test := range []struct {
+		call func() (*Response, error)
+		path string
+		form string
+	}{
+		{func() (*Response, error) { return lc.Vote("t1_c", Downvote) },
+			"/api/vote", "api_type=json&dir=-1&id=t1_c"},
+		{func() (*Response, error) { return lc.Save("t3_l", "go") },
+			"/api/save", "api_type=json&category=go&id=t3_l"},
+		{func() (*Response, error) { return lc.Unsave("t3_l") },
+			"/api/unsave", "api_type=json&id=t3_l"},
+		{func() (*Response, error) { return lc.Hide("t3_a", "t3_b") },
+			"/api/hide", "api_type=json&id=t3_a%2Ct3_b"},
+		{func() (*Response, error) { return lc.Unhide("t3_a") },
+			"/api/unhide", "api_type=json&id=t3_a"},
+		{func() (*Response, error) {
+			return lc.Report(&ReportRequest{Thing: "t1_c", RuleReason: "No spam"})
+		}, "/api/report", "api_type=json&rule_reason=No+spam&thing_id=t1_c"},
+		{func() (*Response, error) { return lc.Lock("t1_c") },
+			"/api/lock", "api_type=json&id=t1_c"},
+		{func() (*Response, error) { return lc.Unlock("t3_l") },
+			"/api/unlock", "api_type=json&id=t3_l"},
+		{func() (*Response, error) { return lc.MarkNSFW("t3_l") },
+			"/api/marknsfw", "api_type=json&id=t3_l"},
+		{func() (*Response, error) { return lc.UnmarkNSFW("t3_l") },
+			"/api/unmarknsfw", "api_type=json&id=t3_l"},
+		{func() (*Response, error) { return lc.Spoiler("t3_l") },
+			"/api/spoiler", "api_type=json&id=t3_l"},
+		{func() (*Response, error) { return lc.Unspoiler("t3_l") },
+			"/api/unspoiler", "api_type=json&id=t3_l"},
+		{func() (*Response, error) { return lc.SendReplies("t1_c", false) },
+			"/api/sendreplies", "api_type=json&id=t1_c&state=false"},
+		{func() (*Response, error) { return lc.SetContestMode("t3_l", true) },
+			"/api/set_contest_mode", "api_type=json&id=t3_l&state=true"},
+		{func() (*Response, error) { return lc.SetSuggestedSort("t3_l", CommentSortQA) },
+			"/api/set_suggested_sort", "api_type=json&id=t3_l&sort=qa"},
+		{func() (*Response, error) { return lc.SetSuggestedSort("t3_l", "") },
+			"/api/set_suggested_sort", "api_type=json&id=t3_l&sort=blank"},
+		{func() (*Response, error) {
+			return lc.SetSubredditSticky("t3_l", &StickyRequest{State: true, Num: 2})
+		}, "/api/set_subreddit_sticky", "api_type=json&id=t3_l&num=2&state=true"},
+	} {
+		if _, err := test.call(); err != nil {
+			t.Errorf("Test(%d): %s", i, err)
+			continue
+		}
+		if path != test.path || form.Encode() != test.form {
+			t.Errorf("Test(%d) posted %s to %s instead of %s to %s",
+				i, form.Encode(), path, test.form, test.path)
+		}
+	}
+}
+
+func TestLinksCommentsThingActionsInvalid(t *testing.T) {
+	lc := NewClient(nil).LinksComments
+	for i, call := range []func() (*Response, error){
+		func() (*Response, error) { return lc.Vote("t1_c", 2) },
+		func() (*Response, error) { return lc.Vote("t5_s", Upvote) },
+		func() (*Response, error) { return lc.Save("t4_m", "") },
+		func() (*Response, error) { return lc.Hide() },
+		func() (*Response, error) { return lc.Hide("t3_a", "t1_c") },
+		func() (*Response, error) { return lc.Report(&ReportRequest{Thing: "t2_u"}) },
+		func() (*Response, error) { return lc.MarkNSFW("t1_c") },
+		func() (*Response, error) { return lc.SetContestMode("t1_c", true) },
+	} {
+		if _, err := call(); err == nil {
+			t.Errorf("Test(%d) returned no error", i)
+		}
+	}
+}
+
+func TestLinksCommentsSavedCategories(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/saved_categories" {
+			t.Errorf("Path was '%s'", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"categories": [{"category": "go"}, {"category": "rust"}]}`)
+	})
+	defer ts.Close()
+	categories, _, err := client.LinksComments.SavedCategories()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := strings.Join(categories, ","); s != "go,rust" {
+		t.Errorf("Categories were %s instead of go,rust", s)
+	}
+}
+
+func TestLinksCommentsReportNil(t *testing.T) {
+	if _, err := NewClient(nil).LinksComments.Report(nil); err == nil {
+		t.Error("No error returned")
+	}
+}
+
+func TestLinksCommentsSetSubredditStickyNil(t *testing.T) {
+	if _, err := NewClient(nil).LinksComments.SetSubredditSticky("t3_l", nil); err == nil {
+		t.Error("No error returned")
+	}
+}
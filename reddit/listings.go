@@ -1,37 +1,461 @@
 package reddit
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
+	"sync"
 )
 
 // ListingsService is the API Endpoint for listings
 type ListingsService service
 
+// listingResponse is the envelope reddit wraps a Listing in.
+type listingResponse struct {
+	Kind string  `json:"kind"`
+	Data Listing `json:"data"`
+}
+
+// getListing requests the listing at path with the url values of opts
+// added to its query and decodes the children into T.
+func getListing[T any](ctx context.Context, c *Client, path string, opts interface{}) ([]T, *Response, error) {
+	return getListingWith(ctx, c, path, opts, decodeListing[T])
+}
+
+// getListingWith is like getListing but decodes the children
+// with decode.
+func getListingWith[T any](ctx context.Context, c *Client, path string, opts interface{}, decode func(*Listing) ([]T, error)) ([]T, *Response, error) {
+	path, err := addOptions(path, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := c.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var listing listingResponse
+	resp, err := c.DoContext(ctx, r, &listing)
+	if err != nil {
+		return nil, resp, err
+	}
+	resp.populateListing(&listing.Data)
+	items, err := decode(&listing.Data)
+	if err != nil {
+		return nil, resp, err
+	}
+	return items, resp, nil
+}
+
 // ByID returns a listing of Links by fullname.
-func (s *ListingsService) ByID(linkNames ...string) ([]Link, error) {
+func (s *ListingsService) ByID(linkNames ...Fullname) ([]Link, *Response, error) {
+	return s.ByIDContext(context.Background(), linkNames...)
+}
+
+// ByIDContext is like ByID but uses the given context for the request.
+func (s *ListingsService) ByIDContext(ctx context.Context, linkNames ...Fullname) ([]Link, *Response, error) {
 	for _, n := range linkNames {
-		if !strings.HasPrefix(n, string(kindLink)) {
-			return nil, fmt.Errorf("%s is no fullname of a link", n)
+		if err := n.validateKind(KindLink); err != nil {
+			return nil, nil, err
 		}
 	}
-	r, err := s.client.NewRequest("GET", "/by_id/"+strings.Join(linkNames, ","), nil)
+	return getListing[Link](ctx, s.client, "/by_id/"+joinFullnames(linkNames), nil)
+}
+
+// TimeFilter restricts the top and controversial listings
+// to links of the given time span.
+type TimeFilter string
+
+const (
+	TimeHour  TimeFilter = "hour"
+	TimeDay   TimeFilter = "day"
+	TimeWeek  TimeFilter = "week"
+	TimeMonth TimeFilter = "month"
+	TimeYear  TimeFilter = "year"
+	TimeAll   TimeFilter = "all"
+)
+
+// HotOptions are the parameters of the hot listing.
+type HotOptions struct {
+	ListOptions
+
+	// Geo filter, e.g. "GLOBAL", "US" or "DE".
+	// Only applies to the front page.
+	Geo string `url:"g,omitempty"`
+}
+
+// TimeOptions are the parameters of the top
+// and controversial listings.
+type TimeOptions struct {
+	ListOptions
+
+	// Time span of the listing. Reddit defaults to TimeDay.
+	Time TimeFilter `url:"t,omitempty"`
+}
+
+// subredditPath returns the path of the sorted listing of subreddit,
+// or of the front page if subreddit is empty.
+func subredditPath(subreddit, sort string) string {
+	if subreddit == "" {
+		return "/" + sort
+	}
+	return "/r/" + url.PathEscape(subreddit) + "/" + sort
+}
+
+// Hot returns the hot links of subreddit, or of the front page
+// if subreddit is empty. opts may be nil.
+func (s *ListingsService) Hot(subreddit string, opts *HotOptions) ([]Link, *Response, error) {
+	return s.HotContext(context.Background(), subreddit, opts)
+}
+
+// HotContext is like Hot but uses the given context for the request.
+func (s *ListingsService) HotContext(ctx context.Context, subreddit string, opts *HotOptions) ([]Link, *Response, error) {
+	return getListing[Link](ctx, s.client, subredditPath(subreddit, "hot"), opts)
+}
+
+// New returns the newest links of subreddit, or of the front page
+// if subreddit is empty. opts may be nil.
+func (s *ListingsService) New(subreddit string, opts *ListOptions) ([]Link, *Response, error) {
+	return s.NewContext(context.Background(), subreddit, opts)
+}
+
+// NewContext is like New but uses the given context for the request.
+func (s *ListingsService) NewContext(ctx context.Context, subreddit string, opts *ListOptions) ([]Link, *Response, error) {
+	return getListing[Link](ctx, s.client, subredditPath(subreddit, "new"), opts)
+}
+
+// Rising returns the rising links of subreddit, or of the front page
+// if subreddit is empty. opts may be nil.
+func (s *ListingsService) Rising(subreddit string, opts *ListOptions) ([]Link, *Response, error) {
+	return s.RisingContext(context.Background(), subreddit, opts)
+}
+
+// RisingContext is like Rising but uses the given context for the request.
+func (s *ListingsService) RisingContext(ctx context.Context, subreddit string, opts *ListOptions) ([]Link, *Response, error) {
+	return getListing[Link](ctx, s.client, subredditPath(subreddit, "rising"), opts)
+}
+
+// Top returns the top links of subreddit, or of the front page
+// if subreddit is empty. opts may be nil.
+func (s *ListingsService) Top(subreddit string, opts *TimeOptions) ([]Link, *Response, error) {
+	return s.TopContext(context.Background(), subreddit, opts)
+}
+
+// TopContext is like Top but uses the given context for the request.
+func (s *ListingsService) TopContext(ctx context.Context, subreddit string, opts *TimeOptions) ([]Link, *Response, error) {
+	return getListing[Link](ctx, s.client, subredditPath(subreddit, "top"), opts)
+}
+
+// Controversial returns the most controversial links of subreddit,
+// or of the front page if subreddit is empty. opts may be nil.
+func (s *ListingsService) Controversial(subreddit string, opts *TimeOptions) ([]Link, *Response, error) {
+	return s.ControversialContext(context.Background(), subreddit, opts)
+}
+
+// ControversialContext is like Controversial but uses the given
+// context for the request.
+func (s *ListingsService) ControversialContext(ctx context.Context, subreddit string, opts *TimeOptions) ([]Link, *Response, error) {
+	return getListing[Link](ctx, s.client, subredditPath(subreddit, "controversial"), opts)
+}
+
+// CommentSort is the sort order of comments.
+type CommentSort string
+
+const (
+	CommentSortConfidence    CommentSort = "confidence"
+	CommentSortTop           CommentSort = "top"
+	CommentSortNew           CommentSort = "new"
+	CommentSortControversial CommentSort = "controversial"
+	CommentSortOld           CommentSort = "old"
+	CommentSortRandom        CommentSort = "random"
+	CommentSortQA            CommentSort = "qa"
+	CommentSortLive          CommentSort = "live"
+)
+
+// CommentsOptions are the parameters of the comments of a link.
+type CommentsOptions struct {
+	// Sort order of the comments. Reddit defaults to the
+	// suggested sort of the link, or CommentSortConfidence.
+	Sort CommentSort `url:"sort,omitempty"`
+
+	// Maximum depth of the comment tree.
+	Depth int `url:"depth,omitempty"`
+
+	// Maximum number of comments to return.
+	Limit int `url:"limit,omitempty"`
+
+	// Number of parents of the focused Comment to include, 0 to 8.
+	Context int `url:"context,omitempty"`
+
+	// Fullname of a comment to focus on. The comment tree
+	// is then rooted at this comment.
+	Comment Fullname `url:"-"`
+}
+
+// LinkComments is a link together with its tree of comments.
+type LinkComments struct {
+	Link *Link
+
+	// The top-level comments
+	Comments Replies
+}
+
+// Comments returns the link article together with its comment tree.
+// opts may be nil.
+func (s *ListingsService) Comments(article Fullname, opts *CommentsOptions) (*LinkComments, *Response, error) {
+	return s.CommentsContext(context.Background(), article, opts)
+}
+
+// CommentsContext is like Comments but uses the given context for the request.
+func (s *ListingsService) CommentsContext(ctx context.Context, article Fullname, opts *CommentsOptions) (*LinkComments, *Response, error) {
+	if err := article.validateKind(KindLink); err != nil {
+		return nil, nil, err
+	}
+	values, err := encodeValues(opts)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
-	var listing struct {
-		Data struct {
-			Children []struct {
-				Data Link `json:"data"`
-			} `json:"children"`
-		} `json:"data"`
+	if opts != nil && opts.Comment != "" {
+		if err := opts.Comment.validateKind(KindComment); err != nil {
+			return nil, nil, err
+		}
+		values.Set("comment", opts.Comment.ID())
 	}
-	if _, err := s.client.Do(r, &listing); err != nil {
-		return nil, err
+	path, err := addOptions("/comments/"+article.ID(), values)
+	if err != nil {
+		return nil, nil, err
 	}
-	var links []Link
-	for _, c := range listing.Data.Children {
-		links = append(links, c.Data)
+	r, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Reddit responds with a listing of the link
+	// followed by a listing of the comments.
+	var raw []json.RawMessage
+	resp, err := s.client.DoContext(ctx, r, &raw)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(raw) != 2 {
+		return nil, resp, fmt.Errorf("Expected 2 listings, got %d", len(raw))
+	}
+	var linkListing listingResponse
+	if err := json.Unmarshal(raw[0], &linkListing); err != nil {
+		return nil, resp, err
+	}
+	resp.populateListing(&linkListing.Data)
+	links, err := decodeListing[*Link](&linkListing.Data)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(links) != 1 {
+		return nil, resp, fmt.Errorf("Expected 1 link, got %d", len(links))
+	}
+	lc := &LinkComments{Link: links[0]}
+	if err := json.Unmarshal(raw[1], &lc.Comments); err != nil {
+		return nil, resp, err
+	}
+	return lc, resp, nil
+}
+
+// DuplicatesSort is the sort order of the duplicates of a link.
+type DuplicatesSort string
+
+const (
+	DuplicatesSortNumComments DuplicatesSort = "num_comments"
+	DuplicatesSortNew         DuplicatesSort = "new"
+)
+
+// DuplicatesOptions are the parameters of the duplicates of a link.
+type DuplicatesOptions struct {
+	ListOptions
+
+	// Sort order of the duplicates.
+	Sort DuplicatesSort `url:"sort,omitempty"`
+
+	// Restrict duplicates to the subreddit of this name.
+	Subreddit string `url:"sr,omitempty"`
+
+	// Only return cross-posts of the link.
+	CrosspostsOnly bool `url:"crossposts_only,omitempty"`
+}
+
+// Duplicates returns the link article together with the
+// other submissions of its URL. opts may be nil.
+// Use Response.After to request the next page.
+func (s *ListingsService) Duplicates(article Fullname, opts *DuplicatesOptions) (*Link, []Link, *Response, error) {
+	return s.DuplicatesContext(context.Background(), article, opts)
+}
+
+// DuplicatesContext is like Duplicates but uses the given
+// context for the request.
+func (s *ListingsService) DuplicatesContext(ctx context.Context, article Fullname, opts *DuplicatesOptions) (*Link, []Link, *Response, error) {
+	if err := article.validateKind(KindLink); err != nil {
+		return nil, nil, nil, err
+	}
+	path, err := addOptions("/duplicates/"+article.ID(), opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	r, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// Reddit responds with a listing of the link
+	// followed by a listing of its duplicates.
+	var listings []listingResponse
+	resp, err := s.client.DoContext(ctx, r, &listings)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+	if len(listings) != 2 {
+		return nil, nil, resp, fmt.Errorf("Expected 2 listings, got %d", len(listings))
+	}
+	links, err := decodeListing[*Link](&listings[0].Data)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+	if len(links) != 1 {
+		return nil, nil, resp, fmt.Errorf("Expected 1 link, got %d", len(links))
+	}
+	resp.populateListing(&listings[1].Data)
+	duplicates, err := decodeListing[Link](&listings[1].Data)
+	if err != nil {
+		return nil, nil, resp, err
 	}
-	return links, err
+	return links[0], duplicates, resp, nil
+}
+
+// maxInfoBatches is the maximum number of batches
+// getInfo requests concurrently.
+const maxInfoBatches = 4
+
+// Info returns the links, comments and subreddits with the given
+// fullnames, in the order of names. Things reddit doesn't know are
+// left out. More than 100 names are split into batches, which are
+// requested concurrently; they share the client's rate limit budget.
+// The returned Response is the one of the last batch, Client.Rate
+// reports the rate limit after all of them. If a batch fails, the
+// others are canceled. At least one name is required.
+func (s *ListingsService) Info(names ...Fullname) ([]Thing, *Response, error) {
+	return s.InfoContext(context.Background(), names...)
+}
+
+// InfoContext is like Info but uses the given context for the requests.
+func (s *ListingsService) InfoContext(ctx context.Context, names ...Fullname) ([]Thing, *Response, error) {
+	ids := make([]string, len(names))
+	for i, n := range names {
+		if err := n.validateKind(KindComment, KindLink, KindSubreddit); err != nil {
+			return nil, nil, err
+		}
+		ids[i] = string(n)
+	}
+	found, resp, err := getInfo[Thing](ctx, s.client, "id", ids)
+	if err != nil {
+		return nil, resp, err
+	}
+	byName := make(map[Fullname]Thing, len(found))
+	for _, t := range found {
+		byName[thingName(t)] = t
+	}
+	var things []Thing
+	for _, n := range names {
+		if t, ok := byName[n]; ok {
+			things = append(things, t)
+		}
+	}
+	return things, resp, nil
+}
+
+// getInfo requests /api/info for values of the parameter key, in
+// concurrent batches of up to 100 values. It returns the items of
+// all batches in order and the Response of the last batch. The first
+// batch that fails cancels the others, its error is returned.
+func getInfo[T any](ctx context.Context, c *Client, key string, values []string) ([]T, *Response, error) {
+	if len(values) == 0 {
+		return nil, nil, errors.New("Nothing to look up")
+	}
+	var batches [][]string
+	for len(values) > 0 {
+		n := len(values)
+		if n > maxListingLimit {
+			n = maxListingLimit
+		}
+		batches = append(batches, values[:n])
+		values = values[n:]
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		results = make([][]T, len(batches))
+		resps   = make([]*Response, len(batches))
+		sem     = make(chan struct{}, maxInfoBatches)
+		wg      sync.WaitGroup
+		once    sync.Once
+		errResp *Response
+		err     error
+	)
+	fail := func(resp *Response, e error) {
+		once.Do(func() {
+			errResp, err = resp, e
+			cancel()
+		})
+	}
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch []string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				fail(nil, ctx.Err())
+				return
+			}
+			var e error
+			results[i], resps[i], e = getListing[T](ctx, c, "/api/info",
+				url.Values{key: {strings.Join(batch, ",")}})
+			if e != nil {
+				fail(resps[i], e)
+			}
+		}(i, batch)
+	}
+	wg.Wait()
+	if err != nil {
+		return nil, errResp, err
+	}
+	var items []T
+	for _, r := range results {
+		items = append(items, r...)
+	}
+	return items, resps[len(resps)-1], nil
+}
+
+// InfoByURL returns the links that have been submitted with the
+// given URL. opts may be nil.
+func (s *ListingsService) InfoByURL(linkURL string, opts *ListOptions) ([]Link, *Response, error) {
+	return s.InfoByURLContext(context.Background(), linkURL, opts)
+}
+
+// InfoByURLContext is like InfoByURL but uses the given context
+// for the request.
+func (s *ListingsService) InfoByURLContext(ctx context.Context, linkURL string, opts *ListOptions) ([]Link, *Response, error) {
+	path, err := addOptions("/api/info", url.Values{"url": {linkURL}})
+	if err != nil {
+		return nil, nil, err
+	}
+	return getListing[Link](ctx, s.client, path, opts)
+}
+
+// InfoBySubredditNames returns the subreddits with the given names.
+// More than 100 names are split into batches like in Info.
+func (s *ListingsService) InfoBySubredditNames(names ...string) ([]Subreddit, *Response, error) {
+	return s.InfoBySubredditNamesContext(context.Background(), names...)
+}
+
+// InfoBySubredditNamesContext is like InfoBySubredditNames but uses
+// the given context for the requests.
+func (s *ListingsService) InfoBySubredditNamesContext(ctx context.Context, names ...string) ([]Subreddit, *Response, error) {
+	return getInfo[Subreddit](ctx, s.client, "sr_name", names)
 }
@@ -1,11 +1,16 @@
 package reddit
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestListingsByIDAPIInternalError(t *testing.T) {
@@ -21,7 +26,7 @@ func TestListingsByIDAPIInternalError(t *testing.T) {
 		t.Fatal(err)
 	}
 	client.BaseURL = u
-	links, err := client.Listings.ByID("t3_asdfgh")
+	links, _, err := client.Listings.ByID("t3_asdfgh")
 	if err == nil {
 		t.Error("No error returned")
 	}
@@ -42,3 +47,391 @@ func TestListingsByIDAPIInternalError(t *testing.T) {
 		t.Error("Links and error returned")
 	}
 }
+
+func TestListingsByIDContextCanceled(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"data": {"children": []}}`)
+	})
+	defer ts.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := client.Listings.ByIDContext(ctx, "t3_asdfgh"); err != context.Canceled {
+		t.Errorf("Returned '%v' instead of context.Canceled", err)
+	}
+}
+
+func TestListingsByIDResponse(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/by_id/t3_asdfgh,t3_qwerty" {
+			t.Errorf("Path was '%s'", r.URL.Path)
+		}
+		w.Header().Set("X-Ratelimit-Used", "1")
+		w.Header().Set("X-Ratelimit-Remaining", "599")
+		w.Header().Set("X-Ratelimit-Reset", "300")
+		fmt.Fprintln(w, `{"kind": "Listing", "data": {
+			"modhash": "m0dh4sh",
+			"after": "t3_qwerty",
+			"before": null,
+			"children": [
+				{"kind": "t3", "data": {"title": "foo"}},
+				{"kind": "t3", "data": {"title": "bar"}}
+			]
+		}}`)
+	})
+	defer ts.Close()
+	links, resp, err := client.Listings.ByID("t3_asdfgh", "t3_qwerty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != 2 || links[0].Title != "foo" || links[1].Title != "bar" {
+		t.Errorf("Links were %#v", links)
+	}
+	if resp.After != "t3_qwerty" || resp.Before != "" || resp.Modhash != "m0dh4sh" {
+		t.Errorf("Response cursors were %q/%q, modhash %q",
+			resp.Before, resp.After, resp.Modhash)
+	}
+	if resp.Rate.Used != 1 || resp.Rate.Remaining != 599 {
+		t.Errorf("Response rate was %#v", resp.Rate)
+	}
+}
+
+func TestListingsByIDInvalidFullname(t *testing.T) {
+	client := NewClient(nil)
+	for _, f := range []Fullname{"t3_", "t30_asdf", "t1_asdf"} {
+		if _, _, err := client.Listings.ByID(f); err == nil {
+			t.Errorf("No error for %s", f)
+		}
+	}
+}
+
+func TestListingsSorted(t *testing.T) {
+	var path, query string
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		q := r.URL.Query()
+		q.Del("raw_json")
+		query = q.Encode()
+		fmt.Fprintln(w, `{"kind": "Listing", "data": {
+			"after": "t3_qwerty",
+			"before": null,
+			"children": [{"kind": "t3", "data": {"name": "t3_qwerty", "title": "foo"}}]
+		}}`)
+	})
+	defer ts.Close()
+	l := client.Listings
+	for i, test := range []struct {
+		call  func() ([]Link, *Response, error)
+		path  string
+		query string
+	}{
+		{func() ([]Link, *Response, error) { return l.Hot("", &HotOptions{Geo: "DE"}) },
+			"/hot", "g=DE"},
+		{func() ([]Link, *Response, error) { return l.Hot("golang", nil) },
+			"/r/golang/hot", ""},
+		{func() ([]Link, *Response, error) { return l.New("golang", &ListOptions{Limit: 5, After: "t3_a"}) },
+			"/r/golang/new", "after=t3_a&limit=5"},
+		{func() ([]Link, *Response, error) { return l.Rising("", nil) },
+			"/rising", ""},
+		{func() ([]Link, *Response, error) { return l.Top("golang", &TimeOptions{Time: TimeWeek}) },
+			"/r/golang/top", "t=week"},
+		{func() ([]Link, *Response, error) {
+			return l.Controversial("", &TimeOptions{ListOptions{Count: 25}, TimeAll})
+		}, "/controversial", "count=25&t=all"},
+	} {
+		links, resp, err := test.call()
+		if err != nil {
+			t.Errorf("Test(%d): %s", i, err)
+			continue
+		}
+		if path != test.path || query != test.query {
+			t.Errorf("Test(%d) requested %s?%s instead of %s?%s",
+				i, path, query, test.path, test.query)
+		}
+		if len(links) != 1 || links[0].Name != "t3_qwerty" || resp.After != "t3_qwerty" {
+			t.Errorf("Test(%d) returned %#v with After '%s'", i, links, resp.After)
+		}
+	}
+}
+
+const testCommentsJSON = `[
+	{"kind": "Listing", "data": {"after": null, "before": null, "modhash": "", "children": [
+		{"kind": "t3", "data": {"id": "5572gp", "name": "t3_5572gp", "title": "Go 1.8", "edited": false}}
+	]}},
+	{"kind": "Listing", "data": {"after": null, "before": null, "modhash": "", "children": [
+		{"kind": "t1", "data": {
+			"id": "c1", "name": "t1_c1", "parent_id": "t3_5572gp", "depth": 0,
+			"author": "alice", "edited": 1475000000.0,
+			"replies": {"kind": "Listing", "data": {"after": null, "before": null, "children": [
+				{"kind": "t1", "data": {
+					"id": "c2", "name": "t1_c2", "parent_id": "t1_c1", "depth": 1,
+					"author": "bob", "edited": false, "replies": ""
+				}},
+				{"kind": "more", "data": {
+					"id": "c3", "name": "t1_c3", "parent_id": "t1_c1", "depth": 1,
+					"count": 2, "children": ["c3", "c4"]
+				}}
+			]}}
+		}},
+		{"kind": "t1", "data": {
+			"id": "c5", "name": "t1_c5", "parent_id": "t3_5572gp", "depth": 0,
+			"author": "carol", "edited": true, "replies": ""
+		}},
+		{"kind": "more", "data": {
+			"id": "c6", "name": "t1_c6", "parent_id": "t3_5572gp", "depth": 0,
+			"count": 1, "children": ["c6"]
+		}}
+	]}}
+]`
+
+func TestListingsComments(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/comments/5572gp" {
+			t.Errorf("Path was '%s'", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("sort") != "new" || q.Get("depth") != "2" || q.Get("comment") != "c1" {
+			t.Errorf("Query was '%s'", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, testCommentsJSON)
+	})
+	defer ts.Close()
+	lc, _, err := client.Listings.Comments("t3_5572gp", &CommentsOptions{
+		Sort:    CommentSortNew,
+		Depth:   2,
+		Comment: "t1_c1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lc.Link.Title != "Go 1.8" {
+		t.Errorf("Link was %#v", lc.Link)
+	}
+	top := lc.Comments.Comments
+	if len(top) != 2 || top[0].Author != "alice" || top[1].Author != "carol" {
+		t.Fatalf("Top-level comments were %#v", top)
+	}
+	if lc.Comments.More == nil || lc.Comments.More.Children[0] != "c6" {
+		t.Errorf("Top-level More was %#v", lc.Comments.More)
+	}
+	replies := top[0].Replies
+	if len(replies.Comments) != 1 || replies.Comments[0].Author != "bob" {
+		t.Errorf("Replies were %#v", replies.Comments)
+	}
+	if replies.More == nil || replies.More.Count != 2 || replies.More.ParentID != "t1_c1" {
+		t.Errorf("More of replies was %#v", replies.More)
+	}
+	if n := len(replies.Comments[0].Replies.Comments); n != 0 {
+		t.Errorf("Empty replies decoded into %d comments", n)
+	}
+}
+
+func TestListingsCommentsInvalidFullname(t *testing.T) {
+	client := NewClient(nil)
+	if _, _, err := client.Listings.Comments("t1_c1", nil); err == nil {
+		t.Error("No error for a comment as article")
+	}
+	if _, _, err := client.Listings.Comments("t3_5572gp", &CommentsOptions{Comment: "t3_a"}); err == nil {
+		t.Error("No error for a link as focus comment")
+	}
+}
+
+func TestListingsInfo(t *testing.T) {
+	var requests int32
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/api/info" {
+			t.Errorf("Path was '%s'", r.URL.Path)
+		}
+		ids := strings.Split(r.URL.Query().Get("id"), ",")
+		if len(ids) > maxListingLimit {
+			t.Errorf("Requested %d things at once", len(ids))
+		}
+		w.Header().Set("X-Ratelimit-Used", strconv.Itoa(len(ids)))
+		w.Header().Set("X-Ratelimit-Remaining", "500")
+		w.Header().Set("X-Ratelimit-Reset", "300")
+		var children []string
+		for i := len(ids) - 1; i >= 0; i-- {
+			if ids[i] == "t3_missing" {
+				continue
+			}
+			children = append(children, fmt.Sprintf(`{"kind": "%s", "data": {"name": "%s"}}`,
+				ids[i][:2], ids[i]))
+		}
+		fmt.Fprintf(w, `{"kind": "Listing", "data": {"children": [%s]}}`, strings.Join(children, ","))
+	})
+	defer ts.Close()
+	var names []Fullname
+	for i := 0; i < 250; i++ {
+		kind := []Kind{KindComment, KindLink, KindSubreddit}[i%3]
+		names = append(names, Fullname(string(kind)+"_"+strconv.Itoa(i)))
+	}
+	names = append(names, "t3_missing")
+	things, resp, err := client.Listings.Info(names...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Rate.Used != 51 {
+		t.Errorf("Returned the response of a batch of %d", resp.Rate.Used)
+	}
+	if requests != 3 {
+		t.Errorf("Made %d requests", requests)
+	}
+	if len(things) != 250 {
+		t.Fatalf("Returned %d things", len(things))
+	}
+	for i, thing := range things {
+		if thingName(thing) != names[i] || thing.Kind() != names[i].Kind() {
+			t.Fatalf("Thing %d was %#v instead of %s", i, thing, names[i])
+		}
+	}
+	if _, ok := things[1].(*Link); !ok {
+		t.Errorf("Thing 1 was %T", things[1])
+	}
+}
+
+func TestListingsInfoInvalidFullname(t *testing.T) {
+	client := NewClient(nil)
+	if _, _, err := client.Listings.Info("t3_a", "t2_b"); err == nil {
+		t.Error("No error for t2_b")
+	}
+}
+
+func TestListingsInfoBy(t *testing.T) {
+	var query url.Values
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprintln(w, `{"kind": "Listing", "data": {"after": "t3_b", "children": [
+			{"kind": "t3", "data": {"name": "t3_a"}},
+			{"kind": "t5", "data": {"name": "t5_c"}}
+		]}}`)
+	})
+	defer ts.Close()
+	links, resp, err := client.Listings.InfoByURL("https://golang.org/", &ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.Get("url") != "https://golang.org/" || query.Get("limit") != "2" {
+		t.Errorf("Query was %v", query)
+	}
+	if len(links) != 1 || links[0].Name != "t3_a" || resp.After != "t3_b" {
+		t.Errorf("Returned %#v with After '%s'", links, resp.After)
+	}
+	subs, _, err := client.Listings.InfoBySubredditNames("golang", "programming")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.Get("sr_name") != "golang,programming" {
+		t.Errorf("Query was %v", query)
+	}
+	if len(subs) != 1 || subs[0].Name != "t5_c" {
+		t.Errorf("Returned %#v", subs)
+	}
+}
+
+func TestListingsInfoBySubredditNamesBatches(t *testing.T) {
+	var requests int32
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		var children []string
+		for _, name := range strings.Split(r.URL.Query().Get("sr_name"), ",") {
+			children = append(children, fmt.Sprintf(`{"kind": "t5", "data": {"display_name": "%s"}}`, name))
+		}
+		fmt.Fprintf(w, `{"kind": "Listing", "data": {"children": [%s]}}`, strings.Join(children, ","))
+	})
+	defer ts.Close()
+	var names []string
+	for i := 0; i < 150; i++ {
+		names = append(names, "sub"+strconv.Itoa(i))
+	}
+	subs, _, err := client.Listings.InfoBySubredditNames(names...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("Made %d requests", requests)
+	}
+	if len(subs) != 150 || subs[0].DisplayName != "sub0" || subs[149].DisplayName != "sub149" {
+		t.Errorf("Returned %d subreddits", len(subs))
+	}
+}
+
+func TestListingsInfoBySubredditNamesCancel(t *testing.T) {
+	var requests int32
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(503)
+			fmt.Fprintln(w, `{"message": "Server fuckup","error": 503}`)
+			return
+		}
+		// The other batches wait until the first one cancels them.
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+			t.Error("Batch was not canceled")
+		}
+	})
+	defer ts.Close()
+	var names []string
+	for i := 0; i < 10*maxListingLimit; i++ {
+		names = append(names, "sub"+strconv.Itoa(i))
+	}
+	_, _, err := client.Listings.InfoBySubredditNames(names...)
+	if _, ok := err.(*APIError); !ok {
+		t.Errorf("Error was '%#v' instead of an APIError", err)
+	}
+	if n := atomic.LoadInt32(&requests); n > maxInfoBatches {
+		t.Errorf("Made %d requests after the first batch failed", n)
+	}
+}
+
+func TestListingsInfoEmpty(t *testing.T) {
+	client := NewClient(nil)
+	if _, _, err := client.Listings.Info(); err == nil {
+		t.Error("No error for Info without names")
+	}
+	if _, _, err := client.Listings.InfoBySubredditNames(); err == nil {
+		t.Error("No error for InfoBySubredditNames without names")
+	}
+}
+
+func TestListingsDuplicates(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/duplicates/orig" {
+			t.Errorf("Path was '%s'", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("sort") != "new" || q.Get("crossposts_only") != "true" || q.Get("limit") != "1" {
+			t.Errorf("Query was '%s'", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `[
+			{"kind": "Listing", "data": {"children": [
+				{"kind": "t3", "data": {"name": "t3_orig", "url": "https://golang.org/"}}
+			]}},
+			{"kind": "Listing", "data": {"after": "t3_x1", "children": [
+				{"kind": "t3", "data": {"name": "t3_x1", "crosspost_parent": "t3_orig",
+					"crosspost_parent_list": [{"name": "t3_orig", "url": "https://golang.org/"}]}}
+			]}}
+		]`)
+	})
+	defer ts.Close()
+	link, dups, resp, err := client.Listings.Duplicates("t3_orig", &DuplicatesOptions{
+		ListOptions:    ListOptions{Limit: 1},
+		Sort:           DuplicatesSortNew,
+		CrosspostsOnly: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Name != "t3_orig" {
+		t.Errorf("Link was %#v", link)
+	}
+	if len(dups) != 1 || dups[0].CrosspostParent != "t3_orig" || resp.After != "t3_x1" {
+		t.Fatalf("Returned %#v with After '%s'", dups, resp.After)
+	}
+	if l := dups[0].CrosspostParentList; len(l) != 1 || l[0].URL != "https://golang.org/" {
+		t.Errorf("Cross-post parents were %#v", l)
+	}
+	if _, _, _, err := client.Listings.Duplicates("t1_orig", nil); err == nil {
+		t.Error("No error for t1_orig")
+	}
+}
@@ -1,6 +1,9 @@
 package reddit
 
-import "encoding/json"
+import (
+	"context"
+	"net/url"
+)
 
 // MiscService is the API Endpoint for misc things
 type MiscService service
@@ -10,23 +13,25 @@ type MiscService service
 // Invalid scope(s) will result in a 400 error with body that
 // indicates the invalid scope(s).
 func (s *MiscService) Scopes(scope string) (*Scopes, *Response, error) {
-	r, err := s.client.NewRequest("GET", "/api/v1/scopes", nil)
-	if err != nil {
-		panic(err)
-	}
+	return s.ScopesContext(context.Background(), scope)
+}
+
+// ScopesContext is like Scopes but uses the given context for the request.
+func (s *MiscService) ScopesContext(ctx context.Context, scope string) (*Scopes, *Response, error) {
+	u := "/api/v1/scopes"
 	if scope != "" {
-		r.URL.Query().Add("scope", scope)
+		u += "?" + url.Values{"scope": {scope}}.Encode()
 	}
-	resp, err := s.client.client.Do(r)
+	r, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
-	rp := &Response{Response: resp}
 	scopes := make(Scopes)
-	if err := json.NewDecoder(resp.Body).Decode(&scopes); err != nil {
-		return nil, rp, err
+	resp, err := s.client.DoContext(ctx, r, &scopes)
+	if err != nil {
+		return nil, resp, err
 	}
-	return &scopes, rp, nil
+	return &scopes, resp, nil
 }
 
 // OAuth permissions scopes for authentication.
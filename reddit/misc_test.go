@@ -1,7 +1,41 @@
 package reddit
 
-import "testing"
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
 
 func TestMiscScopes(t *testing.T) {
 	//client := NewClient(nil)
 }
+
+func TestMiscScopesQuery(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if s := r.URL.Query().Get("scope"); s != "read" {
+			t.Errorf("scope was '%s' instead of 'read'", s)
+		}
+		fmt.Fprint(w, `{"read": {"description": "Read posts", "id": "read", "name": "Read Content"}}`)
+	})
+	defer ts.Close()
+	scopes, _, err := client.Misc.Scopes("read")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := (*scopes)["read"].Name; s != "Read Content" {
+		t.Errorf("Name was '%s' instead of 'Read Content'", s)
+	}
+}
+
+func TestMiscScopesBadRequest(t *testing.T) {
+	client := NewClient(nil)
+	client.BaseURL = &url.URL{Scheme: "http", Host: "bad host"}
+	scopes, resp, err := client.Misc.Scopes("read")
+	if err == nil {
+		t.Error("No error returned")
+	}
+	if scopes != nil || resp != nil {
+		t.Error("Scopes or response returned with error")
+	}
+}
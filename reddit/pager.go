@@ -0,0 +1,180 @@
+package reddit
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// maxListingLimit is the maximum number of items reddit
+// returns for a single page of a listing.
+const maxListingLimit = 100
+
+// ListOptions are the common parameters of listing endpoints.
+type ListOptions struct {
+	// Maximum number of items per page, at most 100.
+	// Reddit defaults to 25.
+	Limit int `url:"limit,omitempty"`
+
+	// Number of items already seen in this listing.
+	Count int `url:"count,omitempty"`
+
+	// Set to "all" to show items that would otherwise be
+	// filtered, e.g. hidden links.
+	Show string `url:"show,omitempty"`
+
+	// Fullname of an item to use as anchor. Only one
+	// of After and Before should be set.
+	After  string `url:"after,omitempty"`
+	Before string `url:"before,omitempty"`
+
+	// Maximum number of items a Pager yields in total.
+	// Zero means no limit. It is not sent to reddit.
+	Max int `url:"-"`
+}
+
+// Pager walks a listing page by page by following its After cursor,
+// decoding the children into T. T is usually a concrete thing type
+// like Link, in which case children of other kinds are skipped, or
+// the Thing interface to get all children. Requests go through the
+// Client, so they share its rate limit budget and retry policy.
+//
+//	p := reddit.NewPager[reddit.Link](client, "/r/golang/new", &reddit.ListOptions{Max: 200})
+//	for p.Next() {
+//		fmt.Println(p.Item().Title)
+//	}
+//	if err := p.Err(); err != nil {
+//		// handle err
+//	}
+type Pager[T any] struct {
+	client *Client
+	path   string
+	opts   ListOptions
+	seen   int
+	done   bool
+
+	page []T
+	item T
+	resp *Response
+	err  error
+
+	// decode decodes the children of each page.
+	decode func(*Listing) ([]T, error)
+}
+
+// NewPager returns a Pager for the listing at path, which may carry
+// endpoint specific parameters in its query. opts may be nil.
+func NewPager[T any](c *Client, path string, opts *ListOptions) *Pager[T] {
+	p := &Pager[T]{client: c, path: path, decode: decodeListing[T]}
+	if opts != nil {
+		p.opts = *opts
+	}
+	return p
+}
+
+// NextPage returns the items of the next page. Once the listing is
+// exhausted or Max items have been yielded, it returns no items
+// and a nil error.
+func (p *Pager[T]) NextPage() ([]T, *Response, error) {
+	return p.NextPageContext(context.Background())
+}
+
+// NextPageContext is like NextPage but uses the given context for the request.
+func (p *Pager[T]) NextPageContext(ctx context.Context) ([]T, *Response, error) {
+	if p.done {
+		return nil, nil, nil
+	}
+	opts := p.opts
+	opts.Count += p.seen
+	if p.opts.Max > 0 {
+		remaining := p.opts.Max - p.seen
+		if remaining <= maxListingLimit && (opts.Limit == 0 || opts.Limit > remaining) {
+			opts.Limit = remaining
+		}
+	}
+	items, resp, err := getListingWith(ctx, p.client, p.path, &opts, p.decode)
+	if err != nil {
+		return nil, resp, err
+	}
+	if p.opts.Max > 0 && p.seen+len(items) >= p.opts.Max {
+		items = items[:p.opts.Max-p.seen]
+		p.done = true
+	}
+	p.seen += len(items)
+	p.opts.After, p.opts.Before = resp.After, ""
+	if resp.After == "" || len(items) == 0 {
+		p.done = true
+	}
+	p.resp = resp
+	return items, resp, nil
+}
+
+// Next advances the Pager to the next item, which is then available
+// through Item. It fetches the next page when necessary and returns
+// false once the listing is exhausted or an error occurred.
+func (p *Pager[T]) Next() bool {
+	return p.NextContext(context.Background())
+}
+
+// NextContext is like Next but uses the given context for requests.
+func (p *Pager[T]) NextContext(ctx context.Context) bool {
+	for len(p.page) == 0 {
+		if p.done || p.err != nil {
+			return false
+		}
+		p.page, _, p.err = p.NextPageContext(ctx)
+	}
+	p.item, p.page = p.page[0], p.page[1:]
+	return true
+}
+
+// Item returns the current item of the Pager.
+func (p *Pager[T]) Item() T {
+	return p.item
+}
+
+// Err returns the error that stopped Next, if any.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// Response returns the Response of the most recently fetched page.
+func (p *Pager[T]) Response() *Response {
+	return p.resp
+}
+
+// Done reports whether the listing is exhausted.
+func (p *Pager[T]) Done() bool {
+	return p.done && len(p.page) == 0
+}
+
+// decodeListing decodes the children of l into T. If T is one of the
+// concrete thing types, e.g. Link or *Link, or the Thing interface,
+// the decoded Thing of each child is used and children of other kinds
+// are skipped. Any other T is decoded from the raw data of each child.
+func decodeListing[T any](l *Listing) ([]T, error) {
+	var zero T
+	_, isThing := any(&zero).(Thing)
+	if _, ok := any(zero).(Thing); ok {
+		isThing = true
+	}
+	items := make([]T, 0, len(l.Children))
+	for _, c := range l.Children {
+		switch v := any(c.Thing).(type) {
+		case T:
+			items = append(items, v)
+			continue
+		case *T:
+			items = append(items, *v)
+			continue
+		}
+		if isThing {
+			continue
+		}
+		var item T
+		if err := json.Unmarshal(c.Data, &item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,101 @@
+package reddit
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newPagerTestClient serves a listing of t3_0 to t3_{total-1}
+// in pages of the requested limit.
+func newPagerTestClient(t *testing.T, total int) (*Client, *httptest.Server) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if s := q.Get("t"); s != "week" {
+			t.Errorf("Endpoint parameter t was '%s' instead of 'week'", s)
+		}
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		if limit == 0 {
+			limit = 25
+		}
+		start := 0
+		if after := q.Get("after"); after != "" {
+			start, _ = strconv.Atoi(strings.TrimPrefix(after, "t3_"))
+			start++
+		}
+		if count, _ := strconv.Atoi(q.Get("count")); count != start {
+			t.Errorf("count was %d instead of %d", count, start)
+		}
+		var children []string
+		last := -1
+		for i := start; i < start+limit && i < total; i++ {
+			children = append(children,
+				fmt.Sprintf(`{"kind": "t3", "data": {"title": "%d"}}`, i))
+			last = i
+		}
+		after := "null"
+		if last >= 0 && last < total-1 {
+			after = fmt.Sprintf(`"t3_%d"`, last)
+		}
+		fmt.Fprintf(w, `{"kind": "Listing", "data": {"after": %s, "before": null, "children": [%s]}}`,
+			after, strings.Join(children, ","))
+	})
+	return client, ts
+}
+
+func TestPagerNext(t *testing.T) {
+	client, ts := newPagerTestClient(t, 7)
+	defer ts.Close()
+	p := NewPager[Link](client, "/top?t=week", &ListOptions{Limit: 3})
+	var titles []string
+	for p.Next() {
+		titles = append(titles, p.Item().Title)
+	}
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if s := strings.Join(titles, ","); s != "0,1,2,3,4,5,6" {
+		t.Errorf("Titles were %s instead of 0,1,2,3,4,5,6", s)
+	}
+	if !p.Done() {
+		t.Error("Pager is not done")
+	}
+}
+
+func TestPagerMax(t *testing.T) {
+	client, ts := newPagerTestClient(t, 50)
+	defer ts.Close()
+	p := NewPager[Link](client, "/top?t=week", &ListOptions{Limit: 3, Max: 5})
+	var pages []int
+	for {
+		links, _, err := p.NextPage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(links) == 0 {
+			break
+		}
+		pages = append(pages, len(links))
+	}
+	if fmt.Sprint(pages) != "[3 2]" {
+		t.Errorf("Page sizes were %v instead of [3 2]", pages)
+	}
+}
+
+func TestPagerError(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(503)
+		fmt.Fprintln(w, `{"message": "Server fuckup","error": 503}`)
+	})
+	defer ts.Close()
+	p := NewPager[Link](client, "/new", nil)
+	if p.Next() {
+		t.Error("Next returned true")
+	}
+	if _, ok := p.Err().(*APIError); !ok {
+		t.Errorf("Err was '%#v' instead of an APIError", p.Err())
+	}
+}
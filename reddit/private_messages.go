@@ -0,0 +1,443 @@
+package reddit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// PrivateMessagesService is the API endpoint for the inbox
+// of the logged-in user.
+type PrivateMessagesService service
+
+// Mailbox is one of the message listings of the logged-in user.
+type Mailbox string
+
+const (
+	// Private messages and comment replies.
+	MailboxInbox Mailbox = "inbox"
+
+	// Unread private messages and comment replies.
+	MailboxUnread Mailbox = "unread"
+
+	// Private messages sent by the user.
+	MailboxSent Mailbox = "sent"
+
+	// Private messages as conversations, the replies
+	// of each conversation are in Message.Replies.
+	MailboxMessages Mailbox = "messages"
+
+	// Replies to comments of the user.
+	MailboxComments Mailbox = "comments"
+
+	// Replies to links of the user.
+	MailboxSelfReply Mailbox = "selfreply"
+
+	// Mentions of the username in comments.
+	MailboxMentions Mailbox = "mentions"
+)
+
+// MessageOptions are the parameters of message listings.
+type MessageOptions struct {
+	ListOptions
+
+	// Mark the returned messages as read. It is always
+	// sent, so messages are left unread by default.
+	Mark bool `url:"mark"`
+}
+
+// Messages returns a page of the given mailbox. Comment replies and
+// mentions are returned as Message with WasComment set and the
+// fullname of the comment as Name. opts may be nil.
+func (s *PrivateMessagesService) Messages(box Mailbox, opts *MessageOptions) ([]Message, *Response, error) {
+	return s.MessagesContext(context.Background(), box, opts)
+}
+
+// MessagesContext is like Messages but uses the given context for the request.
+func (s *PrivateMessagesService) MessagesContext(ctx context.Context, box Mailbox, opts *MessageOptions) ([]Message, *Response, error) {
+	if opts == nil {
+		opts = new(MessageOptions)
+	}
+	return getListingWith(ctx, s.client, mailboxPath(box), opts, decodeMessages)
+}
+
+// Pager returns a Pager over the given mailbox. opts may be nil.
+func (s *PrivateMessagesService) Pager(box Mailbox, opts *MessageOptions) *Pager[Message] {
+	if opts == nil {
+		opts = new(MessageOptions)
+	}
+	path := mailboxPath(box) + "?" + url.Values{"mark": {strconv.FormatBool(opts.Mark)}}.Encode()
+	p := NewPager[Message](s.client, path, &opts.ListOptions)
+	p.decode = decodeMessages
+	return p
+}
+
+// decodeMessages decodes the children of a mailbox listing. Comment
+// replies and mentions are sent with the kind of a comment, but with
+// the fields of a message, so they are decoded into Message as well.
+func decodeMessages(l *Listing) ([]Message, error) {
+	messages := make([]Message, 0, len(l.Children))
+	for _, c := range l.Children {
+		if c.Kind != KindMessage && c.Kind != KindComment {
+			continue
+		}
+		var m Message
+		if err := json.Unmarshal(c.Data, &m); err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, nil
+}
+
+func mailboxPath(box Mailbox) string {
+	return "/message/" + url.PathEscape(string(box))
+}
+
+// MessageThread is a conversation of private messages.
+type MessageThread struct {
+	// Fullname of the first message of the conversation.
+	Name Fullname
+
+	// Messages of the conversation, oldest first. The first message
+	// is missing if it was not among the messages the thread was
+	// built from.
+	Messages []*Message
+}
+
+// Threads groups private messages into conversations by the first
+// message of each conversation. Replies nested in Message.Replies are
+// included, duplicates and comment replies are left out. Messages
+// without first_message_name are assigned by following their ParentID
+// through the given messages. Threads are ordered by the first
+// appearance of one of their messages in messages.
+func Threads(messages []Message) []*MessageThread {
+	var all []*Message
+	byName := make(map[Fullname]*Message)
+	var add func(m *Message)
+	add = func(m *Message) {
+		if m.WasComment || byName[m.Name] != nil {
+			return
+		}
+		byName[m.Name] = m
+		all = append(all, m)
+		for _, r := range m.Replies {
+			add(r)
+		}
+	}
+	for i := range messages {
+		add(&messages[i])
+	}
+	var threads []*MessageThread
+	byThread := make(map[Fullname]*MessageThread)
+	for _, m := range all {
+		name := threadName(m, byName)
+		thread := byThread[name]
+		if thread == nil {
+			thread = &MessageThread{Name: name}
+			byThread[name] = thread
+			threads = append(threads, thread)
+		}
+		thread.Messages = append(thread.Messages, m)
+	}
+	for _, t := range threads {
+		sort.SliceStable(t.Messages, func(i, j int) bool {
+			return t.Messages[i].CreatedUTC < t.Messages[j].CreatedUTC
+		})
+	}
+	return threads
+}
+
+// threadName returns the fullname of the first message of the
+// conversation of m. If m lacks first_message_name, its parents are
+// followed through messages as far as they are known.
+func threadName(m *Message, messages map[Fullname]*Message) Fullname {
+	// Bounded in case of a cycle of parents.
+	for i := 0; i <= len(messages); i++ {
+		if m.FirstMessageName != nil && *m.FirstMessageName != "" {
+			return *m.FirstMessageName
+		}
+		if m.ParentID == nil || *m.ParentID == "" {
+			break
+		}
+		parent, ok := messages[*m.ParentID]
+		if !ok {
+			return *m.ParentID
+		}
+		m = parent
+	}
+	return m.Name
+}
+
+// ComposeRequest is a private message to send.
+type ComposeRequest struct {
+	// Username of the recipient, or "/r/name" to
+	// message the moderators of a subreddit.
+	To string `url:"to"`
+
+	// Subject of the message, up to 100 characters.
+	Subject string `url:"subject"`
+
+	// Raw markdown text of the message.
+	Text string `url:"text"`
+
+	// Name of a subreddit the user moderates, without the /r/
+	// prefix, to send the message as that subreddit.
+	FromSubreddit string `url:"from_sr,omitempty"`
+}
+
+// Compose sends a private message. Reddit reports problems with the
+// message as JSONErrors, which can be matched with errors.Is against
+// e.g. ErrUserDoesntExist, ErrNoSubject or ErrNoText.
+func (s *PrivateMessagesService) Compose(cr *ComposeRequest) (*Response, error) {
+	return s.ComposeContext(context.Background(), cr)
+}
+
+// ComposeContext is like Compose but uses the given context for the request.
+func (s *PrivateMessagesService) ComposeContext(ctx context.Context, cr *ComposeRequest) (*Response, error) {
+	if cr == nil {
+		return nil, errors.New("Can't send a nil ComposeRequest")
+	}
+	return s.client.postForm(ctx, "/api/compose", &struct {
+		*ComposeRequest
+		APIType string `url:"api_type"`
+	}{cr, "json"}, nil)
+}
+
+// ReadMessage marks messages and comment replies as read.
+func (s *PrivateMessagesService) ReadMessage(names ...Fullname) (*Response, error) {
+	return s.ReadMessageContext(context.Background(), names...)
+}
+
+// ReadMessageContext is like ReadMessage but uses the given
+// context for the request.
+func (s *PrivateMessagesService) ReadMessageContext(ctx context.Context, names ...Fullname) (*Response, error) {
+	return s.postMessages(ctx, "/api/read_message", names, KindMessage, KindComment)
+}
+
+// UnreadMessage marks messages and comment replies as unread.
+func (s *PrivateMessagesService) UnreadMessage(names ...Fullname) (*Response, error) {
+	return s.UnreadMessageContext(context.Background(), names...)
+}
+
+// UnreadMessageContext is like UnreadMessage but uses the given
+// context for the request.
+func (s *PrivateMessagesService) UnreadMessageContext(ctx context.Context, names ...Fullname) (*Response, error) {
+	return s.postMessages(ctx, "/api/unread_message", names, KindMessage, KindComment)
+}
+
+// ReadAllMessages marks the whole inbox as read. Reddit
+// processes the request asynchronously.
+func (s *PrivateMessagesService) ReadAllMessages() (*Response, error) {
+	return s.ReadAllMessagesContext(context.Background())
+}
+
+// ReadAllMessagesContext is like ReadAllMessages but uses the
+// given context for the request.
+func (s *PrivateMessagesService) ReadAllMessagesContext(ctx context.Context) (*Response, error) {
+	return s.client.postForm(ctx, "/api/read_all_messages", url.Values{"api_type": {"json"}}, nil)
+}
+
+// DeleteMessage deletes a private message from the inbox.
+func (s *PrivateMessagesService) DeleteMessage(message Fullname) (*Response, error) {
+	return s.DeleteMessageContext(context.Background(), message)
+}
+
+// DeleteMessageContext is like DeleteMessage but uses the given
+// context for the request.
+func (s *PrivateMessagesService) DeleteMessageContext(ctx context.Context, message Fullname) (*Response, error) {
+	return s.postMessages(ctx, "/api/del_msg", []Fullname{message}, KindMessage)
+}
+
+// Block blocks the author of a private message or comment reply.
+func (s *PrivateMessagesService) Block(thing Fullname) (*Response, error) {
+	return s.BlockContext(context.Background(), thing)
+}
+
+// BlockContext is like Block but uses the given context for the request.
+func (s *PrivateMessagesService) BlockContext(ctx context.Context, thing Fullname) (*Response, error) {
+	return s.postMessages(ctx, "/api/block", []Fullname{thing}, KindMessage, KindComment)
+}
+
+// Collapse collapses private messages in the inbox.
+func (s *PrivateMessagesService) Collapse(messages ...Fullname) (*Response, error) {
+	return s.CollapseContext(context.Background(), messages...)
+}
+
+// CollapseContext is like Collapse but uses the given context for the request.
+func (s *PrivateMessagesService) CollapseContext(ctx context.Context, messages ...Fullname) (*Response, error) {
+	return s.postMessages(ctx, "/api/collapse_message", messages, KindMessage)
+}
+
+// Uncollapse expands collapsed private messages again.
+func (s *PrivateMessagesService) Uncollapse(messages ...Fullname) (*Response, error) {
+	return s.UncollapseContext(context.Background(), messages...)
+}
+
+// UncollapseContext is like Uncollapse but uses the given
+// context for the request.
+func (s *PrivateMessagesService) UncollapseContext(ctx context.Context, messages ...Fullname) (*Response, error) {
+	return s.postMessages(ctx, "/api/uncollapse_message", messages, KindMessage)
+}
+
+// postMessages posts the fullnames of names as id to path,
+// after checking that they are of one of the kinds.
+func (s *PrivateMessagesService) postMessages(ctx context.Context, path string, names []Fullname, kinds ...Kind) (*Response, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("No messages given")
+	}
+	for _, n := range names {
+		if err := n.validateKind(kinds...); err != nil {
+			return nil, err
+		}
+	}
+	return s.client.postForm(ctx, path, url.Values{
+		"api_type": {"json"},
+		"id":       {joinFullnames(names)},
+	}, nil)
+}
+
+// inboxFlushTimeout bounds marking handled messages as read
+// once the context of StreamInbox is done.
+const inboxFlushTimeout = 10 * time.Second
+
+// MessageHandler handles a private message or comment reply
+// passed by StreamInbox. Comment replies have WasComment set.
+type MessageHandler func(ctx context.Context, m *Message) error
+
+// StreamInbox polls the unread messages and comment replies of the
+// logged-in user and passes each one to handle, oldest first. A message
+// is marked as read only after handle returned nil for it, in batches
+// after every poll. If handle fails, the message is passed again once
+// a delay has passed, which starts at MinInterval and doubles with every
+// failure up to MaxInterval; other messages are not held up by it.
+// With SkipExisting, the messages that are unread when the
+// stream starts are never passed and stay unread. Errors of handle and
+// of requests are sent to the returned channel, after which polling
+// continues with backoff; it has to be drained. Once ctx is done, the
+// messages handled so far are marked as read and the channel is closed.
+// opts may be nil.
+func (s *PrivateMessagesService) StreamInbox(ctx context.Context, handle MessageHandler, opts *StreamOptions) <-chan error {
+	o := streamOptions(opts)
+	errs := make(chan error, 1)
+	send := func(err error) {
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case errs <- err:
+		case <-ctx.Done():
+		}
+	}
+	go func() {
+		defer close(errs)
+		// Handled messages stay in the unread listing until they
+		// are marked as read, seen keeps them from being handled twice.
+		seen := newSeenSet(o.SeenSize)
+		// Skipped messages stay unread, so they are kept apart
+		// from seen, which forgets its oldest names.
+		skipped := make(map[Fullname]bool)
+		retries := make(map[Fullname]*inboxRetry)
+		var read []Fullname
+		interval := o.MinInterval
+		for first := true; ctx.Err() == nil; first = false {
+			page, _, err := s.MessagesContext(ctx, MailboxUnread,
+				&MessageOptions{ListOptions: ListOptions{Limit: maxListingLimit}})
+			failed := err != nil
+			if err != nil {
+				send(err)
+			}
+			handled := 0
+			// Listings are sorted newest first.
+			for i := len(page) - 1; i >= 0 && ctx.Err() == nil; i-- {
+				m := &page[i]
+				if seen.has(m.Name) || skipped[m.Name] {
+					continue
+				}
+				if first && o.SkipExisting {
+					skipped[m.Name] = true
+					continue
+				}
+				retry := retries[m.Name]
+				if retry != nil && time.Now().Before(retry.next) {
+					continue
+				}
+				if err := handle(ctx, m); err != nil {
+					if retry == nil {
+						retry = &inboxRetry{delay: o.MinInterval}
+						retries[m.Name] = retry
+					} else {
+						retry.delay = o.nextInterval(retry.delay, false)
+					}
+					retry.next = time.Now().Add(retry.delay)
+					send(fmt.Errorf("Handling %s: %w", m.Name, err))
+					continue
+				}
+				delete(retries, m.Name)
+				seen.add(m.Name)
+				read = append(read, m.Name)
+				handled++
+			}
+			if ctx.Err() != nil {
+				break
+			}
+			if err == nil {
+				// Messages that left the unread listing
+				// need no backoff anymore.
+				unread := make(map[Fullname]bool, len(page))
+				for i := range page {
+					unread[page[i].Name] = true
+				}
+				for name := range retries {
+					if !unread[name] {
+						delete(retries, name)
+					}
+				}
+			}
+			if read, err = s.readBatches(ctx, read); err != nil {
+				failed = true
+				send(err)
+			}
+			interval = o.nextInterval(interval, handled > 0 && !failed)
+			if err := sleepUntil(ctx, time.Now().Add(interval)); err != nil {
+				break
+			}
+		}
+		flushCtx, cancel := context.WithTimeout(context.Background(), inboxFlushTimeout)
+		defer cancel()
+		if _, err := s.readBatches(flushCtx, read); err != nil {
+			select {
+			case errs <- err:
+			default:
+			}
+		}
+	}()
+	return errs
+}
+
+// inboxRetry is the backoff of a message StreamInbox failed to handle.
+type inboxRetry struct {
+	delay time.Duration
+	next  time.Time
+}
+
+// readBatches marks names as read, in batches of up to 100 names.
+// It returns the names that could not be marked.
+func (s *PrivateMessagesService) readBatches(ctx context.Context, names []Fullname) ([]Fullname, error) {
+	for len(names) > 0 {
+		n := len(names)
+		if n > maxListingLimit {
+			n = maxListingLimit
+		}
+		if _, err := s.ReadMessageContext(ctx, names[:n]...); err != nil {
+			return names, err
+		}
+		names = names[n:]
+	}
+	return nil, nil
+}
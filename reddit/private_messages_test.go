@@ -0,0 +1,467 @@
+package reddit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testInboxJSON = `{"kind": "Listing", "data": {"after": "t4_m2", "children": [
+	{"kind": "t1", "data": {"id": "c1", "name": "t1_c1", "author": "alice", "body": "nice post",
+		"was_comment": true, "type": "post_reply", "link_title": "Go 1.8", "context": "/r/golang/comments/l/_/c1/?context=3",
+		"parent_id": "t3_l", "first_message": null, "first_message_name": null, "replies": "", "new": true}},
+	{"kind": "t4", "data": {"id": "m2", "name": "t4_m2", "author": "bob", "body": "re: hi",
+		"was_comment": false, "type": "unknown", "dest": "gopher", "parent_id": "t4_m1",
+		"first_message": 1, "first_message_name": "t4_m1", "replies": "", "new": true}}
+]}}`
+
+func TestPrivateMessagesMessages(t *testing.T) {
+	var paths []string
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if mark := r.URL.Query().Get("mark"); mark != "false" {
+			t.Errorf("mark was '%s'", mark)
+		}
+		fmt.Fprint(w, testInboxJSON)
+	})
+	defer ts.Close()
+	for _, box := range []Mailbox{MailboxInbox, MailboxUnread, MailboxSent, MailboxMessages,
+		MailboxComments, MailboxSelfReply, MailboxMentions} {
+		messages, resp, err := client.PrivateMessages.Messages(box, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p := paths[len(paths)-1]; p != "/message/"+string(box) {
+			t.Errorf("Requested %s for %s", p, box)
+		}
+		if len(messages) != 2 || resp.After != "t4_m2" {
+			t.Fatalf("Returned %#v with After '%s'", messages, resp.After)
+		}
+	}
+	messages, _, _ := client.PrivateMessages.Messages(MailboxInbox, nil)
+	c := messages[0]
+	if !c.WasComment || c.Name != "t1_c1" || c.Type != "post_reply" || c.LinkTitle != "Go 1.8" {
+		t.Errorf("Comment reply was %#v", c)
+	}
+	m := messages[1]
+	if m.WasComment || m.Name != "t4_m2" || m.Dest != "gopher" ||
+		m.FirstMessage == nil || *m.FirstMessage != 1 || *m.FirstMessageName != "t4_m1" {
+		t.Errorf("Message was %#v", m)
+	}
+}
+
+func TestPrivateMessagesPager(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/message/unread" || q.Get("mark") != "true" || q.Get("limit") != "5" {
+			t.Errorf("Requested %s", r.URL)
+		}
+		if q.Get("after") == "" {
+			fmt.Fprint(w, testInboxJSON)
+			return
+		}
+		fmt.Fprint(w, `{"kind": "Listing", "data": {"children": []}}`)
+	})
+	defer ts.Close()
+	p := client.PrivateMessages.Pager(MailboxUnread, &MessageOptions{ListOptions{Limit: 5}, true})
+	var names []Fullname
+	for p.Next() {
+		names = append(names, p.Item().Name)
+	}
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 || names[0] != "t1_c1" || names[1] != "t4_m2" {
+		t.Errorf("Paged %v", names)
+	}
+}
+
+func TestMessageReplies(t *testing.T) {
+	var m Message
+	err := json.Unmarshal([]byte(`{"name": "t4_m1", "replies": {"kind": "Listing", "data": {"children": [
+		{"kind": "t4", "data": {"name": "t4_m2", "first_message_name": "t4_m1", "replies": ""}},
+		{"kind": "t4", "data": {"name": "t4_m3", "first_message_name": "t4_m1", "replies": ""}}
+	]}}}`), &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Replies) != 2 || m.Replies[0].Name != "t4_m2" || m.Replies[1].Name != "t4_m3" {
+		t.Errorf("Replies were %#v", m.Replies)
+	}
+	if err := json.Unmarshal([]byte(`{"replies": ""}`), &m); err != nil || m.Replies != nil {
+		t.Errorf("Empty replies decoded into %#v, %v", m.Replies, err)
+	}
+}
+
+func TestThreads(t *testing.T) {
+	s := func(s Fullname) *Fullname { return &s }
+	messages := []Message{
+		{Name: "t4_b2", Created: Created{CreatedUTC: 5}, FirstMessageName: s("t4_b1"), ParentID: s("t4_b1")},
+		{Name: "t1_c", WasComment: true},
+		{Name: "t4_a1", Created: Created{CreatedUTC: 1}, Replies: MessageReplies{
+			{Name: "t4_a3", Created: Created{CreatedUTC: 4}, FirstMessageName: s("t4_a1")},
+			{Name: "t4_a2", Created: Created{CreatedUTC: 2}, FirstMessageName: s("t4_a1")},
+		}},
+		{Name: "t4_b3", Created: Created{CreatedUTC: 6}, ParentID: s("t4_b2")},
+		{Name: "t4_a2", Created: Created{CreatedUTC: 2}, FirstMessageName: s("t4_a1")},
+		// Without first_message_name, the parents lead to the root.
+		{Name: "t4_d3", Created: Created{CreatedUTC: 9}, ParentID: s("t4_d2")},
+		{Name: "t4_d1", Created: Created{CreatedUTC: 7}},
+		{Name: "t4_d2", Created: Created{CreatedUTC: 8}, ParentID: s("t4_d1")},
+	}
+	threads := Threads(messages)
+	if len(threads) != 3 {
+		t.Fatalf("Built %d threads", len(threads))
+	}
+	for i, want := range []struct {
+		name     Fullname
+		messages []Fullname
+	}{
+		{"t4_b1", []Fullname{"t4_b2", "t4_b3"}},
+		{"t4_a1", []Fullname{"t4_a1", "t4_a2", "t4_a3"}},
+		{"t4_d1", []Fullname{"t4_d1", "t4_d2", "t4_d3"}},
+	} {
+		th := threads[i]
+		var names []Fullname
+		for _, m := range th.Messages {
+			names = append(names, m.Name)
+		}
+		if th.Name != want.name || fmt.Sprint(names) != fmt.Sprint(want.messages) {
+			t.Errorf("Thread %d was %s with %v", i, th.Name, names)
+		}
+	}
+}
+
+func TestPrivateMessagesActions(t *testing.T) {
+	var (
+		path string
+		form url.Values
+	)
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		path, form = r.URL.Path, r.PostForm
+		fmt.Fprint(w, `{"json": {"errors": []}}`)
+	})
+	defer ts.Close()
+	pm := client.PrivateMessages
+	for i, test := range []struct {
+		call func() (*Response, error)
+		path string
+		form string
+	}{
+		{func() (*Response, error) {
+			return pm.Compose(&ComposeRequest{To: "alice", Subject: "hi", Text: "hello", FromSubreddit: "golang"})
+		}, "/api/compose", "api_type=json&from_sr=golang&subject=hi&text=hello&to=alice"},
+		{func() (*Response, error) { return pm.ReadMessage("t4_a", "t1_b") },
+			"/api/read_message", "api_type=json&id=t4_a%2Ct1_b"},
+		{func() (*Response, error) { return pm.UnreadMessage("t4_a") },
+			"/api/unread_message", "api_type=json&id=t4_a"},
+		{pm.ReadAllMessages, "/api/read_all_messages", "api_type=json"},
+		{func() (*Response, error) { return pm.DeleteMessage("t4_a") },
+			"/api/del_msg", "api_type=json&id=t4_a"},
+		{func() (*Response, error) { return pm.Block("t1_b") },
+			"/api/block", "api_type=json&id=t1_b"},
+		{func() (*Response, error) { return pm.Collapse("t4_a", "t4_b") },
+			"/api/collapse_message", "api_type=json&id=t4_a%2Ct4_b"},
+		{func() (*Response, error) { return pm.Uncollapse("t4_a") },
+			"/api/uncollapse_message", "api_type=json&id=t4_a"},
+	} {
+		if _, err := test.call(); err != nil {
+			t.Errorf("Test(%d): %s", i, err)
+			continue
+		}
+		if path != test.path || form.Encode() != test.form {
+			t.Errorf("Test(%d) posted %s to %s instead of %s to %s",
+				i, form.Encode(), path, test.form, test.path)
+		}
+	}
+}
+
+func TestPrivateMessagesActionsInvalid(t *testing.T) {
+	pm := NewClient(nil).PrivateMessages
+	for i, call := range []func() (*Response, error){
+		func() (*Response, error) { return pm.ReadMessage() },
+		func() (*Response, error) { return pm.ReadMessage("t3_l") },
+		func() (*Response, error) { return pm.DeleteMessage("t1_c") },
+		func() (*Response, error) { return pm.Block("t2_u") },
+		func() (*Response, error) { return pm.Collapse("t1_c") },
+	} {
+		if _, err := call(); err == nil {
+			t.Errorf("Test(%d) returned no error", i)
+		}
+	}
+}
+
+func TestPrivateMessagesComposeErrors(t *testing.T) {
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("to") == "nobody" {
+			fmt.Fprint(w, `{"json": {"errors": [["USER_DOESNT_EXIST", "that user doesn't exist", "to"]]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"json": {"errors": [["NO_TEXT", "we need something here", "text"]]}}`)
+	})
+	defer ts.Close()
+	_, err := client.PrivateMessages.Compose(&ComposeRequest{To: "nobody", Subject: "hi", Text: "hello"})
+	if !errors.Is(err, ErrUserDoesntExist) || errors.Is(err, ErrNoText) {
+		t.Errorf("Returned %v for unknown user", err)
+	}
+	var jsonErr *JSONError
+	if !errors.As(err, &jsonErr) || jsonErr.Field != "to" {
+		t.Errorf("Returned %#v for unknown user", jsonErr)
+	}
+	_, err = client.PrivateMessages.Compose(&ComposeRequest{To: "alice", Subject: "hi"})
+	if !errors.Is(err, ErrNoText) || errors.Is(err, ErrUserDoesntExist) {
+		t.Errorf("Returned %v for missing text", err)
+	}
+}
+
+func TestPrivateMessagesComposeNil(t *testing.T) {
+	if _, err := NewClient(nil).PrivateMessages.Compose(nil); err == nil {
+		t.Error("No error returned")
+	}
+}
+
+// newInboxTestClient serves the unread messages of unread, newest last,
+// and removes them once marked as read.
+func newInboxTestClient(t *testing.T, unread *[]string, reads *[]string, mu *sync.Mutex) (*Client, *httptest.Server) {
+	return newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/message/unread":
+			if r.URL.Query().Get("mark") != "false" {
+				t.Errorf("Requested %s", r.URL)
+			}
+			var children []string
+			for i := len(*unread) - 1; i >= 0; i-- {
+				name := (*unread)[i]
+				children = append(children, fmt.Sprintf(`{"kind": "%s", "data": {"name": "%s", "was_comment": %v}}`,
+					name[:2], name, name[:2] == "t1"))
+			}
+			fmt.Fprintf(w, `{"kind": "Listing", "data": {"children": [%s]}}`, strings.Join(children, ","))
+		case "/api/read_message":
+			r.ParseForm()
+			ids := r.PostForm.Get("id")
+			*reads = append(*reads, ids)
+			var left []string
+			for _, name := range *unread {
+				if !strings.Contains(ids, name) {
+					left = append(left, name)
+				}
+			}
+			*unread = left
+			fmt.Fprint(w, `{}`)
+		default:
+			t.Errorf("Path was '%s'", r.URL.Path)
+		}
+	})
+}
+
+func TestPrivateMessagesStreamInbox(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		unread = []string{"t4_1", "t1_2"}
+		reads  []string
+	)
+	client, ts := newInboxTestClient(t, &unread, &reads, &mu)
+	defer ts.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var handled []string
+	failed := false
+	done := make(chan struct{})
+	errs := client.PrivateMessages.StreamInbox(ctx, func(ctx context.Context, m *Message) error {
+		handled = append(handled, string(m.Name))
+		if m.WasComment && !failed {
+			failed = true
+			return fmt.Errorf("try again")
+		}
+		if m.WasComment {
+			close(done)
+		}
+		return nil
+	}, &StreamOptions{MinInterval: time.Millisecond, MaxInterval: 2 * time.Millisecond})
+	select {
+	case err := <-errs:
+		if err == nil || !strings.Contains(err.Error(), "t1_2") {
+			t.Errorf("Error was %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out")
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out")
+	}
+	cancel()
+	for err := range errs {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if s := strings.Join(handled, ","); s != "t4_1,t1_2,t1_2" {
+		t.Errorf("Handled %s", s)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	// Marking t1_2 as read may be repeated on shutdown
+	// if cancel interrupted the first request.
+	if len(unread) != 0 || len(reads) < 2 || reads[0] != "t4_1" || reads[len(reads)-1] != "t1_2" {
+		t.Errorf("Left %v unread after reading %v", unread, reads)
+	}
+}
+
+func TestPrivateMessagesStreamInboxShutdown(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		unread = []string{"t4_1", "t4_2", "t4_3"}
+		reads  []string
+	)
+	client, ts := newInboxTestClient(t, &unread, &reads, &mu)
+	defer ts.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var handled []string
+	errs := client.PrivateMessages.StreamInbox(ctx, func(ctx context.Context, m *Message) error {
+		handled = append(handled, string(m.Name))
+		if m.Name == "t4_2" {
+			cancel()
+		}
+		return nil
+	}, nil)
+	for err := range errs {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if s := strings.Join(handled, ","); s != "t4_1,t4_2" {
+		t.Errorf("Handled %s", s)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reads) != 1 || reads[0] != "t4_1,t4_2" || len(unread) != 1 {
+		t.Errorf("Left %v unread after reading %v", unread, reads)
+	}
+}
+
+func TestPrivateMessagesStreamInboxSkipExisting(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		polls int
+		read  = make(map[string]bool)
+	)
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/api/read_message" {
+			r.ParseForm()
+			for _, name := range strings.Split(r.PostForm.Get("id"), ",") {
+				read[name] = true
+			}
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		polls++
+		// More new messages than fit into the seen set
+		// arrive after the first poll.
+		var children []string
+		for i := 0; polls > 1 && i < 2*maxListingLimit; i++ {
+			if name := "t4_" + strconv.Itoa(i); !read[name] {
+				children = append(children, fmt.Sprintf(`{"kind": "t4", "data": {"name": "%s"}}`, name))
+			}
+		}
+		children = append(children, `{"kind": "t4", "data": {"name": "t4_old"}}`)
+		fmt.Fprintf(w, `{"kind": "Listing", "data": {"children": [%s]}}`, strings.Join(children, ","))
+	})
+	defer ts.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var handled int
+	errs := client.PrivateMessages.StreamInbox(ctx, func(ctx context.Context, m *Message) error {
+		if m.Name == "t4_old" {
+			t.Error("Existing message was handled")
+		}
+		handled++
+		return nil
+	}, &StreamOptions{
+		MinInterval:  time.Millisecond,
+		MaxInterval:  time.Millisecond,
+		SeenSize:     maxListingLimit,
+		SkipExisting: true,
+	})
+	go func() {
+		// Keep polling after all new messages are handled.
+		for {
+			mu.Lock()
+			n := len(read)
+			mu.Unlock()
+			if n >= 2*maxListingLimit || ctx.Err() != nil {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	for err := range errs {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if handled != 2*maxListingLimit {
+		t.Errorf("Handled %d messages", handled)
+	}
+}
+
+func TestPrivateMessagesStreamInboxBackoff(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		polls int
+	)
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/api/read_message" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		// Every poll has a new message besides the one that fails.
+		polls++
+		fmt.Fprintf(w, `{"kind": "Listing", "data": {"children": [
+			{"kind": "t4", "data": {"name": "t4_%d"}},
+			{"kind": "t4", "data": {"name": "t4_bad"}}
+		]}}`, polls)
+	})
+	defer ts.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var handled, attempts int
+	errs := client.PrivateMessages.StreamInbox(ctx, func(ctx context.Context, m *Message) error {
+		if m.Name == "t4_bad" {
+			attempts++
+			return fmt.Errorf("poison")
+		}
+		if handled++; handled == 10 {
+			cancel()
+		}
+		return nil
+	}, &StreamOptions{MinInterval: 10 * time.Millisecond, MaxInterval: time.Hour})
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case _, ok := <-errs:
+			done = !ok
+		case <-timeout:
+			t.Fatal("Timed out, the failing message slowed down polling")
+		}
+	}
+	// The failing message is retried with its own backoff.
+	if attempts < 2 || attempts >= handled {
+		t.Errorf("Failing message was handled %d times during %d polls", attempts, handled)
+	}
+}
@@ -1,6 +1,7 @@
 package reddit
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -40,6 +41,26 @@ func rateLimitErrorFromResp(resp *http.Response) (*RateLimitError, error) {
 	return &e, nil
 }
 
+func rateFromResp(resp *http.Response) (*Rate, error) {
+	var err error
+	t := time.Now()
+	rl := Rate{}
+	rl.Used, err = ratelimitGetInt(resp, "X-Ratelimit-Used")
+	if err != nil {
+		return nil, err
+	}
+	rl.Remaining, err = ratelimitGetInt(resp, "X-Ratelimit-Remaining")
+	if err != nil {
+		return nil, err
+	}
+	resetSec, err := ratelimitGetInt(resp, "X-Ratelimit-Reset")
+	if err != nil {
+		return nil, err
+	}
+	rl.Reset = t.Add(time.Duration(resetSec) * time.Second)
+	return &rl, nil
+}
+
 func ratelimitGetInt(resp *http.Response, header string) (int, error) {
 	//fmt.Printf("%#v\n", *resp)
 	h := resp.Header.Get(header)
@@ -74,7 +95,8 @@ func (e *RateLimitHeaderError) Error() string {
 	return e.Message
 }
 
-type rateLimit struct {
+// Rate is a snapshot of the rate limit budget reported by reddit.
+type Rate struct {
 	// Approximate number of requests used in this period.
 	// Header: X-Ratelimit-Used
 	Used int
@@ -86,3 +108,67 @@ type rateLimit struct {
 	// Time when the RateLimit is reset
 	Reset time.Time
 }
+
+// RateLimitPolicy decides how the Client behaves once the rate limit
+// budget of the current period is used up.
+type RateLimitPolicy int
+
+const (
+	// RateLimitBlock blocks all requests until the period resets.
+	RateLimitBlock RateLimitPolicy = iota
+
+	// RateLimitQueue blocks all requests until the period resets and
+	// then releases them one after another, each waiting for the
+	// response of its predecessor to refresh the known budget.
+	RateLimitQueue
+
+	// RateLimitFailFast returns a *RateLimitError right away
+	// without sending the request.
+	RateLimitFailFast
+)
+
+// awaitRateLimit applies the RateLimitPolicy of the client before
+// a request is sent. The returned release func has to be called
+// once the response has been recorded.
+func (c *Client) awaitRateLimit(ctx context.Context) (release func(), err error) {
+	noop := func() {}
+	rate, ok := c.Rate()
+	if !ok || rate.Remaining > 0 {
+		return noop, nil
+	}
+	switch c.RateLimitPolicy {
+	case RateLimitFailFast:
+		return nil, &RateLimitError{
+			Used:      rate.Used,
+			Remaining: rate.Remaining,
+			Reset:     int((rate.Reset.Sub(time.Now()) + time.Second - 1) / time.Second),
+		}
+	case RateLimitQueue:
+		c.rateQueueMu.Lock()
+		// The budget may have been refreshed while waiting for the lock.
+		if rate, ok = c.Rate(); ok && rate.Remaining <= 0 {
+			if err := sleepUntil(ctx, rate.Reset); err != nil {
+				c.rateQueueMu.Unlock()
+				return nil, err
+			}
+		}
+		return c.rateQueueMu.Unlock, nil
+	default:
+		if err := sleepUntil(ctx, rate.Reset); err != nil {
+			return nil, err
+		}
+		return noop, nil
+	}
+}
+
+// sleepUntil blocks until t or until ctx is done.
+func sleepUntil(ctx context.Context, t time.Time) error {
+	timer := time.NewTimer(t.Sub(time.Now()))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return contextError(ctx, ctx.Err())
+	}
+}
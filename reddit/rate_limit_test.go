@@ -1,8 +1,12 @@
 package reddit
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestRateLimitFetchFromRespError(t *testing.T) {
@@ -76,3 +80,106 @@ func TestRateLimitFetchFromConversion(t *testing.T) {
 		}
 	}
 }
+
+func newRateLimitTestServer(remaining string, hits *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		w.Header().Set("X-Ratelimit-Used", "600")
+		w.Header().Set("X-Ratelimit-Remaining", remaining)
+		w.Header().Set("X-Ratelimit-Reset", "30")
+	}))
+}
+
+func TestRateLimitRecordedByDo(t *testing.T) {
+	var hits int32
+	ts := newRateLimitTestServer("0", &hits)
+	defer ts.Close()
+	client := NewClient(nil)
+	if client.RateLimitHit() {
+		t.Error("RateLimitHit before any request")
+	}
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(req, nil); err != nil {
+		t.Fatal(err)
+	}
+	rate, ok := client.Rate()
+	if !ok {
+		t.Fatal("No rate recorded")
+	}
+	if rate.Used != 600 || rate.Remaining != 0 {
+		t.Errorf("Rate was %#v", rate)
+	}
+	if !client.RateLimitHit() {
+		t.Error("RateLimitHit is false for an exhausted budget")
+	}
+}
+
+func TestRateLimitFailFast(t *testing.T) {
+	var hits int32
+	ts := newRateLimitTestServer("0", &hits)
+	defer ts.Close()
+	client := NewClient(nil)
+	client.RateLimitPolicy = RateLimitFailFast
+	client.rateLimit = &Rate{Used: 600, Reset: time.Now().Add(30 * time.Second)}
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Do(req, nil)
+	rle, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("Returned '%#v' instead of a RateLimitError", err)
+	}
+	if rle.Used != 600 || rle.Reset != 30 {
+		t.Errorf("RateLimitError was %#v", rle)
+	}
+	if hits != 0 {
+		t.Error("Request has been sent despite the exhausted budget")
+	}
+}
+
+func TestRateLimitBlock(t *testing.T) {
+	for _, policy := range []RateLimitPolicy{RateLimitBlock, RateLimitQueue} {
+		var hits int32
+		ts := newRateLimitTestServer("10", &hits)
+		client := NewClient(nil)
+		client.RateLimitPolicy = policy
+		reset := time.Now().Add(50 * time.Millisecond)
+		client.rateLimit = &Rate{Used: 600, Reset: reset}
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.Do(req, nil); err != nil {
+			t.Error(err)
+		}
+		if time.Now().Before(reset) {
+			t.Errorf("Policy %d sent the request before the reset", policy)
+		}
+		ts.Close()
+	}
+}
+
+func TestRateLimitBlockCanceled(t *testing.T) {
+	var hits int32
+	ts := newRateLimitTestServer("10", &hits)
+	defer ts.Close()
+	client := NewClient(nil)
+	client.rateLimit = &Rate{Used: 600, Reset: time.Now().Add(time.Minute)}
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.DoContext(ctx, req, nil)
+	if _, ok := err.(*TimeoutError); !ok {
+		t.Errorf("Returned '%#v' instead of a TimeoutError", err)
+	}
+	if hits != 0 {
+		t.Error("Request has been sent despite the exhausted budget")
+	}
+}
@@ -2,13 +2,16 @@ package reddit
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 )
@@ -19,6 +22,14 @@ type Client struct {
 	UserAgent string
 	BaseURL   *url.URL
 
+	// RateLimitPolicy decides what happens to requests once the
+	// rate limit budget of the current period is used up.
+	RateLimitPolicy RateLimitPolicy
+
+	// RetryPolicy enables automatic retries of failed requests.
+	// Requests are not retried if it is nil.
+	RetryPolicy *RetryPolicy
+
 	common service
 
 	Account         *AccountService
@@ -38,7 +49,8 @@ type Client struct {
 	Wiki            *WikiService
 
 	rateLimitMu sync.Mutex
-	rateLimit   *rateLimit
+	rateLimit   *Rate
+	rateQueueMu sync.Mutex
 }
 
 // Semantic Version
@@ -78,29 +90,58 @@ func NewClient(httpClient *http.Client) *Client {
 	return c
 }
 
+// NewRequest creates an API request. urlStr is resolved relative to
+// the BaseURL of the client. If body is not nil, it is JSON encoded
+// and sent as the request body.
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
-	rel, err := url.Parse(urlStr)
+	if body == nil {
+		return c.newRequest(method, urlStr, nil, "")
+	}
+	buf := new(bytes.Buffer)
+	err := json.NewEncoder(buf).Encode(body)
 	if err != nil {
 		return nil, err
 	}
-	var buf io.ReadWriter
-	if body != nil {
-		buf = new(bytes.Buffer)
-		err := json.NewEncoder(buf).Encode(body)
-		if err != nil {
-			return nil, err
-		}
+	return c.newRequest(method, urlStr, buf, "application/json")
+}
+
+// NewFormRequest creates an API request with a form-urlencoded body,
+// as expected by most of reddit's write endpoints. form is either
+// url.Values or a struct whose fields are tagged with `url:"name"`.
+// See encodeValues for the supported struct fields.
+func (c *Client) NewFormRequest(method, urlStr string, form interface{}) (*http.Request, error) {
+	values, err := encodeValues(form)
+	if err != nil {
+		return nil, err
+	}
+	return c.newRequest(method, urlStr, strings.NewReader(values.Encode()),
+		"application/x-www-form-urlencoded")
+}
+
+// postForm posts form to urlStr and decodes the response into v.
+func (c *Client) postForm(ctx context.Context, urlStr string, form interface{}, v interface{}) (*Response, error) {
+	r, err := c.NewFormRequest("POST", urlStr, form)
+	if err != nil {
+		return nil, err
+	}
+	return c.DoContext(ctx, r, v)
+}
+
+func (c *Client) newRequest(method, urlStr string, body io.Reader, contentType string) (*http.Request, error) {
+	rel, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, err
 	}
 	reqURL := c.BaseURL.ResolveReference(rel)
 	reqQuery := reqURL.Query()
 	reqQuery.Set("raw_json", "1")
 	reqURL.RawQuery = reqQuery.Encode()
-	req, err := http.NewRequest(method, reqURL.String(), buf)
+	req, err := http.NewRequest(method, reqURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 	req.Header.Set("Accept", "application/json")
 	if c.UserAgent != "" {
@@ -109,59 +150,132 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 	return req, nil
 }
 
-func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := c.client.Do(req)
+// Do sends an API request bound to the context of req and decodes
+// the JSON response into v. See DoContext.
+func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
+	return c.DoContext(req.Context(), req, v)
+}
+
+// DoContext sends an API request and decodes the JSON response into v.
+// Cancellation and deadlines of ctx are passed down to the underlying
+// http.Client. A *TimeoutError is returned if the deadline of ctx is
+// exceeded or the http.Client times out.
+//
+// Failed requests are retried according to the client's RetryPolicy.
+func (c *Client) DoContext(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	var (
+		retries    int
+		retryDelay time.Duration
+	)
+	for {
+		resp, err := c.do(ctx, req, v)
+		wait, retry := c.RetryPolicy.delay(req, resp, err, retries)
+		if retry && ctx.Err() == nil {
+			req, retry = rewindRequest(req)
+		}
+		if !retry || ctx.Err() != nil {
+			if resp != nil {
+				resp.Retries = retries
+				resp.RetryDelay = retryDelay
+			}
+			return resp, err
+		}
+		if err := sleepUntil(ctx, time.Now().Add(wait)); err != nil {
+			return resp, err
+		}
+		retries++
+		retryDelay += wait
+	}
+}
+
+// do sends a single attempt of an API request.
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	release, err := c.awaitRateLimit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req.WithContext(ctx))
+	var rate *Rate
+	if err == nil {
+		// Not every response carries rate limit headers,
+		// those simply leave the known budget untouched.
+		rate, _ = c.updateRateLimit(resp)
+	}
+	release()
 	if err != nil {
-		return resp, err
+		return nil, contextError(ctx, err)
 	}
+	// CheckResponse may replace the body with a buffered copy.
+	body := resp.Body
 	defer func() {
 		// Drain up to 512 bytes and close the body to let the Transport reuse the connection
-		io.CopyN(ioutil.Discard, resp.Body, 512)
-		resp.Body.Close()
+		io.CopyN(ioutil.Discard, body, 512)
+		body.Close()
 	}()
+	rp := &Response{Response: resp}
+	if rate != nil {
+		rp.Rate = *rate
+	}
 	if err := CheckResponse(resp); err != nil {
-		return resp, err
+		return rp, err
 	}
 	if v != nil {
-		return resp, json.NewDecoder(resp.Body).Decode(v)
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return rp, contextError(ctx, err)
+		}
 	}
-	return resp, nil
+	return rp, nil
 }
 
-func (c *Client) updateRateLimit(resp *http.Response) error {
-	var err error
-	t := time.Now()
-	rl := rateLimit{}
-	rl.Used, err = ratelimitGetInt(resp, "X-Ratelimit-Used")
-	if err != nil {
-		return err
+// contextError replaces err with a *TimeoutError or the error of ctx
+// if the request has been aborted by a deadline or cancellation.
+func contextError(ctx context.Context, err error) error {
+	switch ctx.Err() {
+	case nil:
+	case context.DeadlineExceeded:
+		return &TimeoutError{Err: ctx.Err()}
+	default:
+		return ctx.Err()
 	}
-	rl.Remaining, err = ratelimitGetInt(resp, "X-Ratelimit-Remaining")
-	if err != nil {
-		return err
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return &TimeoutError{Err: err}
 	}
-	resetSec, err := ratelimitGetInt(resp, "X-Ratelimit-Reset")
+	return err
+}
+
+// updateRateLimit stores the rate limit budget reported by resp.
+func (c *Client) updateRateLimit(resp *http.Response) (*Rate, error) {
+	rl, err := rateFromResp(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	rl.Reset = t.Add(time.Duration(resetSec) * time.Second)
 	c.rateLimitMu.Lock()
 	defer c.rateLimitMu.Unlock()
-	c.rateLimit = &rl
-	return nil
+	c.rateLimit = rl
+	return rl, nil
 }
 
+// RateLimitHit reports whether the rate limit budget of the current
+// period is used up.
 func (c *Client) RateLimitHit() bool {
+	rate, ok := c.Rate()
+	return ok && rate.Remaining <= 0
+}
+
+// Rate returns the most recently observed rate limit budget.
+// ok is false if no rate limit headers have been seen since
+// the last period ended.
+func (c *Client) Rate() (rate Rate, ok bool) {
 	c.rateLimitMu.Lock()
 	defer c.rateLimitMu.Unlock()
 	if c.rateLimit == nil {
-		return false
+		return Rate{}, false
 	}
 	if c.rateLimit.Reset.Before(time.Now()) {
 		c.rateLimit = nil
-		return false
+		return Rate{}, false
 	}
-	return false
+	return *c.rateLimit, true
 }
 
 type (
@@ -177,9 +291,18 @@ const statusCodeRateLimit = 429
 // CheckResponse checks the response for correct status codes
 // and keeps track of rate limits and returns nil if the response
 // if ok. It returns a RateLimitError if the API's rate limiting
-// blocked the response.
+// blocked the response and JSONErrors if reddit reported errors
+// in the `json.errors` array of the body, even for 2xx responses.
 func CheckResponse(resp *http.Response) error {
 	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		rawJSON, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(rawJSON))
+		if errs := jsonErrorsFromBody(rawJSON); errs != nil {
+			return errs
+		}
 		return nil
 	}
 	if resp.StatusCode == statusCodeRateLimit {
@@ -194,6 +317,9 @@ func CheckResponse(resp *http.Response) error {
 	if err != nil {
 		return err
 	}
+	if errs := jsonErrorsFromBody(rawJSON); errs != nil {
+		return errs
+	}
 	err = json.NewDecoder(bytes.NewReader(rawJSON)).Decode(apiErr)
 	if err != nil {
 		apiErr.Message = string(rawJSON)
@@ -237,23 +363,42 @@ type (
 		client *Client
 	}
 
-	AccountService         service
-	CaptchaService         service
-	FlairService           service
-	GoldService            service
-	LinksCommentsService   service
-	LiveThreadsService     service
-	PrivateMessagesService service
-	ModerationService      service
-	MultisService          service
-	SearchService          service
-	SubredditsService      service
-	UsersService           service
-	WikiService            service
+	CaptchaService     service
+	FlairService       service
+	GoldService        service
+	LiveThreadsService service
+	ModerationService  service
+	MultisService      service
+	SearchService      service
+	UsersService       service
+	WikiService        service
 )
 
+// Response wraps the http.Response of a request to the reddit API
+// and carries the metadata reddit sends along.
 type Response struct {
 	*http.Response
+
+	// Rate limit budget reported with the response.
+	// The zero value if the response had no rate limit headers.
+	Rate Rate
+
+	// Fullname of the first item of the listing, used as `before`
+	// to request the previous page. Empty if there is none.
+	Before string
+
+	// Fullname of the last item of the listing, used as `after`
+	// to request the next page. Empty if there is none.
+	After string
+
+	// Modhash sent along with a listing.
+	Modhash string
+
+	// Number of retries done according to the RetryPolicy.
+	Retries int
+
+	// Total time spent waiting between retries.
+	RetryDelay time.Duration
 }
 
 // APIError implements the Error interface and is used to
@@ -267,6 +412,44 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("%d: %s", e.ErrorCode, e.Message)
 }
 
+// TimeoutError is returned if a request did not finish in time, either
+// because the deadline of its context has been exceeded or because the
+// http.Client timed out.
+type TimeoutError struct {
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	return "Request timed out: " + e.Err.Error()
+}
+
+// Timeout reports true, to satisfy the net.Error interface.
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+// Temporary reports true, to satisfy the net.Error interface.
+func (e *TimeoutError) Temporary() bool {
+	return true
+}
+
+// Unwrap returns the underlying error.
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// populateListing copies the pagination cursors and the modhash
+// of the listing l.
+func (r *Response) populateListing(l *Listing) {
+	if l.Before != nil {
+		r.Before = *l.Before
+	}
+	if l.After != nil {
+		r.After = *l.After
+	}
+	r.Modhash = l.ModHash
+}
+
 type rateLimiter struct {
 	err error
 	sync.RWMutex
@@ -1,6 +1,7 @@
 package reddit
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -10,8 +11,18 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
+// newTestClient returns a Client that sends its requests
+// to a test server running handler.
+func newTestClient(handler http.HandlerFunc) (*Client, *httptest.Server) {
+	ts := httptest.NewServer(handler)
+	client := NewClient(nil)
+	client.BaseURL, _ = url.Parse(ts.URL)
+	return client, ts
+}
+
 func TestUserAgent(t *testing.T) {
 	s1 := UserAgent("linux", "tl.foo.bar", "v0.0.1", "/u/anon")
 	s2 := "linux:tl.foo.bar:v0.0.1 (by /u/anon)"
@@ -299,3 +310,56 @@ func TestClientCheckResponseBrokenJSONError(t *testing.T) {
 	}
 
 }
+
+func TestClientDoContextCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewClient(nil).DoContext(ctx, req, nil)
+	if err != context.Canceled {
+		t.Errorf("Returned '%v' instead of context.Canceled", err)
+	}
+}
+
+func TestClientDoContextDeadline(t *testing.T) {
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer ts.Close()
+	defer close(done)
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = NewClient(nil).DoContext(ctx, req, nil)
+	if _, ok := err.(*TimeoutError); !ok {
+		t.Errorf("Returned '%#v' instead of a TimeoutError", err)
+	}
+}
+
+func TestClientDoHTTPClientTimeout(t *testing.T) {
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer ts.Close()
+	defer close(done)
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = NewClient(&http.Client{Timeout: 10 * time.Millisecond}).Do(req, nil)
+	if _, ok := err.(*TimeoutError); !ok {
+		t.Errorf("Returned '%#v' instead of a TimeoutError", err)
+	}
+}
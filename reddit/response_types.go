@@ -2,23 +2,14 @@ package reddit
 
 import "encoding/json"
 
-// Thing is the reddit API's base class
-type Thing struct {
-	// This item's identifier, e.g. "8xwlg"
-	ID string
-
-	// Fullname of comment, e.g. "t1_c3v7f8u"
-	Name string
-
-	// All `thing`s have a `kind`.  The kind is a String identifier
-	// that denotes the object's type.
-	// Some examples: `Listing`, `more`, `t1`, `t2`
-	Kind string
-
-	// Data is a custom data structure used to hold valuable information.
-	// This object's format will follow the data structure respective
-	// of its kind.
-	//Data json.RawMessage
+// Thing is the reddit API's base class. It is implemented by the
+// concrete types a ListingThing is decoded to, e.g. *Comment or *Link.
+//
+// Every thing has an identifier (ID, e.g. "8xwlg"), a fullname
+// (Name, e.g. "t1_c3v7f8u") and a kind, a String identifier that
+// denotes the object's type. Some examples: `Listing`, `more`, `t1`, `t2`
+type Thing interface {
+	Kind() Kind
 }
 
 // Listing is used to paginate content that is too long to display in one go.
@@ -50,13 +41,18 @@ type Listing struct {
 	Children []ListingThing `json:"children"`
 }
 
+// ListingThing is a child of a Listing.
 type ListingThing struct {
 	// All `thing`s have a `kind`.  The kind is a String identifier
 	// that denotes the object's type.
 	// Some examples: `Listing`, `more`, `t1`, `t2`
-	Kind string
+	Kind Kind
 
 	Data json.RawMessage
+
+	// Data decoded into the concrete type for Kind, e.g. *Comment
+	// for KindComment, or an *UnknownThing for unknown kinds.
+	Thing Thing `json:"-"`
 }
 
 type Votable struct {
@@ -84,6 +80,12 @@ type Comment struct {
 	Created
 	Votable
 
+	// This item's identifier, e.g. "c3v7f8u"
+	ID string `json:"id"`
+
+	// Fullname of comment, e.g. "t1_c3v7f8u"
+	Name Fullname `json:"name"`
+
 	// Who approved this comment. null if nobody or you are not a mod
 	ApprovedBy *string `json:"approved_by"`
 
@@ -114,7 +116,7 @@ type Comment struct {
 	// False if not edited, edit date in UTC epoch-seconds otherwise.
 	// NOTE: for some old edited comments on reddit.com,
 	// this will be set to true instead of edit date.
-	Edited string `json:"edited"`
+	Edited json.RawMessage `json:"edited"`
 
 	// The number of times this comment received reddit gold
 	Gilded int `json:"gilded"`
@@ -128,8 +130,8 @@ type Comment struct {
 	// Contains the author of the parent link
 	LinkAuthor *string `json:"link_author"`
 
-	// ID of the link this comment is in
-	LinkID string `json:"link_id"`
+	// Fullname of the link this comment is in
+	LinkID Fullname `json:"link_id"`
 
 	// Present if the comment is being displayed outside its thread
 	// (user pages, /r/subreddit/comments/.json, etc.).
@@ -145,12 +147,16 @@ type Comment struct {
 	// null if not a mod
 	NumReports *int `json:"num_reports"`
 
-	// ID of the thing this comment is a reply to,
+	// Fullname of the thing this comment is a reply to,
 	// either the link or a comment in it
-	ParentID string `json:"parent_id"`
+	ParentID Fullname `json:"parent_id"`
 
-	// A list of replies to this comment
-	Replies []ListingThing `json:"replies"`
+	// Depth of the comment in the comment tree, starting at 0.
+	// Only present if the comment is displayed within its thread.
+	Depth int `json:"depth"`
+
+	// The replies to this comment
+	Replies Replies `json:"replies"`
 
 	// True if this post is saved by the logged in user
 	Saved bool `json:"saved"`
@@ -176,10 +182,27 @@ type Comment struct {
 	Distinguished *string `json:"distinguished"`
 }
 
+// Replies holds the replies to a link or a comment. Reddit sends them
+// as a listing of comments which may end with a More placeholder for
+// replies that have not been loaded, or as an empty string if there
+// are no replies at all.
+type Replies struct {
+	Comments []*Comment
+
+	// Placeholder for further replies, or nil
+	More *More
+}
+
 type Link struct {
 	Votable
 	Created
 
+	// This item's identifier, e.g. "8xwlg"
+	ID string `json:"id"`
+
+	// Fullname of link, e.g. "t3_8xwlg"
+	Name Fullname `json:"name"`
+
 	// The account name of the poster. null if this is
 	// a promotional link
 	Author string `json:"author"`
@@ -299,10 +322,41 @@ type Link struct {
 
 	// True if the post is set as the sticky in its subreddit.
 	Stickied bool `json:"stickied"`
+
+	// True if the post is pinned to the profile of its author.
+	Pinned bool `json:"pinned"`
+
+	// True if the post is marked as spoiler.
+	Spoiler bool `json:"spoiler"`
+
+	// True if comments are shown in contest mode, i.e. in random
+	// order with hidden scores.
+	ContestMode bool `json:"contest_mode"`
+
+	// The sort order suggested for the comments of the post.
+	// Empty if none is suggested.
+	SuggestedSort CommentSort `json:"suggested_sort"`
+
+	// True if replies are sent to the inbox of the author.
+	SendReplies bool `json:"send_replies"`
+
+	// Fullname of the link this link is a cross-post of.
+	// Empty if it is no cross-post.
+	CrosspostParent Fullname `json:"crosspost_parent"`
+
+	// The link this link is a cross-post of, followed by the
+	// links that one is a cross-post of, back to the origin.
+	CrosspostParentList []*Link `json:"crosspost_parent_list"`
 }
 
 type Subreddit struct {
 
+	// This item's identifier, e.g. "2qh0u"
+	ID string `json:"id"`
+
+	// Fullname of subreddit, e.g. "t5_2qh0u"
+	Name Fullname `json:"name"`
+
 	// Number of users active in last 15 minutes
 	AccountsActive int `json:"accounts_active"`
 
@@ -376,6 +430,9 @@ type Subreddit struct {
 type Message struct {
 	Created
 
+	// This item's identifier, e.g. "8xwlg"
+	ID string `json:"id"`
+
 	Author string `json:"author"`
 
 	// The message itself.
@@ -389,10 +446,10 @@ type Message struct {
 	Context string `json:"context"`
 
 	// Either null or the first message's ID represented as base 10 (wtf)
-	FirstMessage *Message `json:"first_message"`
+	FirstMessage *int64 `json:"first_message"`
 
 	// Either null or the first message's fullname
-	FirstMessageName *string `json:"first_message_name"`
+	FirstMessageName *Fullname `json:"first_message_name"`
 
 	// How the logged-in user has voted on the message.
 	// True = upvoted,
@@ -405,16 +462,17 @@ type Message struct {
 	LinkTitle string `json:"link_title"`
 
 	// ex: "t4_8xwlg"
-	Name string `json:"name"`
+	Name Fullname `json:"name"`
 
 	// unread? not sure.
 	New bool `json:"new"`
 
 	// Null if no parent is attached.
-	ParentID *string `json:"parent_id"`
+	ParentID *Fullname `json:"parent_id"`
 
-	// An empty string if there are no replies.
-	Replies string `json:"replies"`
+	// Replies to the message, only included in listings
+	// of whole conversations.
+	Replies MessageReplies `json:"replies"`
 
 	// Subject of message.
 	Subject string `json:"subject"`
@@ -423,8 +481,21 @@ type Message struct {
 	Subreddit string `json:"subreddit"`
 
 	WasComment bool `json:"was_comment"`
+
+	// Type of a comment reply, e.g. "comment_reply", "post_reply"
+	// or "username_mention". "unknown" for messages.
+	Type string `json:"type"`
+
+	// Username of the recipient, or "#subreddit" for
+	// messages to the moderators of a subreddit.
+	Dest string `json:"dest"`
 }
 
+// MessageReplies holds the replies to a private message. Reddit sends
+// them as a listing of messages, or as an empty string if there are
+// no replies at all.
+type MessageReplies []*Message
+
 // Example of raw account data:
 // 	{
 // 		"kind": "t2",
@@ -488,6 +559,53 @@ type Account struct {
 
 	// Whether this account is set to be over 18
 	Over18 bool `json:"over18"`
+
+	// Full URL to the avatar of the account.
+	IconImg string `json:"icon_img"`
+
+	// Sum of link, comment and award karma.
+	TotalKarma int `json:"total_karma"`
+
+	// Whether the account is suspended.
+	IsSuspended bool `json:"is_suspended"`
+
+	// End of the suspension in UTC epoch-second format.
+	// null if the account is not suspended or suspended
+	// permanently.
+	SuspensionExpirationUTC *float64 `json:"suspension_expiration_utc"`
+
+	// The following preferences are only present for
+	// your own account. See AccountService.Preferences
+	// for the full set.
+
+	// Whether media is played automatically.
+	PrefAutoplay bool `json:"pref_autoplay"`
+
+	// Whether thumbnails are expanded on click.
+	PrefClickgadget bool `json:"pref_clickgadget"`
+
+	// Location used for the geo filter of popular links.
+	PrefGeopopular string `json:"pref_geopopular"`
+
+	// Whether profanity is hidden.
+	PrefNoProfanity bool `json:"pref_no_profanity"`
+
+	// Whether night mode is enabled.
+	PrefNightmode bool `json:"pref_nightmode"`
+
+	// Whether trending subreddits are shown.
+	PrefShowTrending bool `json:"pref_show_trending"`
+
+	// Whether a link to the twitter account is
+	// shown on the profile.
+	PrefShowTwitter bool `json:"pref_show_twitter"`
+
+	// Whether the subreddits with the most karma
+	// are shown on the profile.
+	PrefTopKarmaSubreddits bool `json:"pref_top_karma_subreddits"`
+
+	// Whether videos are played automatically.
+	PrefVideoAutoplay bool `json:"pref_video_autoplay"`
 }
 
 // Example of more:
@@ -509,16 +627,71 @@ type More struct {
 	// A list of String `id`s that are the additional `thing`s that can
 	// be downloaded but are not because there are too many to list.
 	Children []string `json:"children"`
+
+	// Number of comments hidden behind this placeholder
+	Count int `json:"count"`
+
+	// This item's identifier, e.g. "c3y9tyh"
+	ID string `json:"id"`
+
+	// Fullname of the first child, e.g. "t1_c3y9tyh"
+	Name Fullname `json:"name"`
+
+	// Fullname of the thing the children reply to
+	ParentID Fullname `json:"parent_id"`
+
+	// Depth of the placeholder in the comment tree
+	Depth int `json:"depth"`
+}
+
+// Example of raw award (trophy) data:
+// 	{
+// 		"kind": "t6",
+// 		"data": {
+// 			"icon_70": "https://www.redditstatic.com/awards2/verified_email-70.png",
+// 			"name": "Verified Email",
+// 			"url": null,
+// 			"icon_40": "https://www.redditstatic.com/awards2/verified_email-40.png",
+// 			"award_id": "o",
+// 			"id": null,
+// 			"description": null
+// 		}
+// 	}
+type Award struct {
+
+	// Identifier of this particular award, null for most awards
+	ID *string `json:"id"`
+
+	// Identifier of the kind of award, e.g. "o"
+	AwardID string `json:"award_id"`
+
+	// Name of the award, e.g. "Verified Email"
+	Name string `json:"name"`
+
+	// Description of the award, or null
+	Description *string `json:"description"`
+
+	// Full URL to a 40x40 pixel icon
+	Icon40 string `json:"icon_40"`
+
+	// Full URL to a 70x70 pixel icon
+	Icon70 string `json:"icon_70"`
+
+	// URL the award links to, or null
+	URL *string `json:"url"`
 }
 
-type kind string
+// Kind is the type prefix of a fullname, e.g. "t1" for comments.
+type Kind string
 
 const (
-	kindComment       kind = "t1"
-	kindAccount       kind = "t2"
-	kindLink          kind = "t3"
-	kindMessage       kind = "t4"
-	kindSubreddit     kind = "t5"
-	kindAward         kind = "t6"
-	kindPromoCampaign kind = "t8"
+	KindComment       Kind = "t1"
+	KindAccount       Kind = "t2"
+	KindLink          Kind = "t3"
+	KindMessage       Kind = "t4"
+	KindSubreddit     Kind = "t5"
+	KindAward         Kind = "t6"
+	KindPromoCampaign Kind = "t8"
+	KindMore          Kind = "more"
+	KindListing       Kind = "Listing"
 )
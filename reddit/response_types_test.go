@@ -30,3 +30,23 @@ func TestResponseTypeAccount(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestResponseTypeLinkState(t *testing.T) {
+	rawJSON := bytes.NewBufferString(`{
+		"id": "5572gp",
+		"name": "t3_5572gp",
+		"pinned": true,
+		"spoiler": true,
+		"contest_mode": true,
+		"suggested_sort": "qa",
+		"send_replies": false,
+		"edited": false
+	}`)
+	var l Link
+	if err := json.NewDecoder(rawJSON).Decode(&l); err != nil {
+		t.Fatal(err)
+	}
+	if !l.Pinned || !l.Spoiler || !l.ContestMode || l.SuggestedSort != CommentSortQA || l.SendReplies {
+		t.Errorf("Link was %#v", l)
+	}
+}
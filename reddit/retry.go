@@ -0,0 +1,123 @@
+package reddit
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of requests that failed with
+// a transport error, a RateLimitError or a 5xx status code.
+type RetryPolicy struct {
+	// Maximum number of attempts including the first one.
+	MaxAttempts int
+
+	// Delay before the first retry. It is doubled for every
+	// further retry and jittered by up to one half.
+	// Defaults to one second.
+	BaseDelay time.Duration
+
+	// Upper bound of the exponential backoff. Zero means no bound.
+	// Delays demanded by reddit through the X-Ratelimit-Reset or
+	// Retry-After headers are not capped.
+	MaxDelay time.Duration
+
+	// Retry non-idempotent requests like POST as well.
+	RetryNonIdempotent bool
+}
+
+const defaultRetryBaseDelay = time.Second
+
+// delay reports whether the request should be retried after the given
+// attempt failed and how long to wait before doing so.
+func (p *RetryPolicy) delay(req *http.Request, resp *Response, err error, retries int) (time.Duration, bool) {
+	if p == nil || err == nil || retries+1 >= p.MaxAttempts {
+		return 0, false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return 0, false
+	}
+	var wait time.Duration
+	switch {
+	case resp == nil:
+		if _, ok := err.(*RateLimitError); ok {
+			// Rejected by the circuit breaker without being sent.
+			return 0, false
+		}
+	case resp.StatusCode == statusCodeRateLimit:
+		if rle, ok := err.(*RateLimitError); ok {
+			wait = time.Duration(rle.Reset) * time.Second
+		}
+	case resp.StatusCode >= 500:
+	default:
+		return 0, false
+	}
+	if resp != nil {
+		if d, ok := retryAfter(resp.Response); ok {
+			wait = d
+		}
+	}
+	if b := p.backoff(retries); b > wait {
+		wait = b
+	}
+	return wait, true
+}
+
+// backoff returns the jittered exponential backoff for the given retry.
+func (p *RetryPolicy) backoff(retries int) time.Duration {
+	d := p.BaseDelay
+	if d <= 0 {
+		d = defaultRetryBaseDelay
+	}
+	for i := 0; i < retries && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses the Retry-After header, which is either
+// a number of seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	h := resp.Header.Get("Retry-After")
+	if h == "" {
+		return 0, false
+	}
+	if sec, err := strconv.Atoi(h); err == nil {
+		return time.Duration(sec) * time.Second, true
+	}
+	if t, err := http.ParseTime(h); err == nil {
+		return t.Sub(time.Now()), true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "TRACE", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// rewindRequest returns a copy of req with a fresh body, so it can be
+// sent again. It reports false if the body can't be restored.
+func rewindRequest(req *http.Request) (*http.Request, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	r := new(http.Request)
+	*r = *req
+	r.Body = body
+	return r, true
+}
@@ -0,0 +1,135 @@
+package reddit
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestServer(fails int32, status int, hits *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(hits, 1) <= fails {
+			w.WriteHeader(status)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+}
+
+func TestRetryServerError(t *testing.T) {
+	var hits int32
+	ts := newRetryTestServer(2, http.StatusServiceUnavailable, &hits)
+	defer ts.Close()
+	client := NewClient(nil)
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Retries != 2 {
+		t.Errorf("Retries was %d instead of 2", resp.Retries)
+	}
+	if resp.RetryDelay <= 0 {
+		t.Error("RetryDelay was not recorded")
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	var hits int32
+	ts := newRetryTestServer(5, http.StatusBadGateway, &hits)
+	defer ts.Close()
+	client := NewClient(nil)
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req, nil)
+	if _, ok := err.(*APIError); !ok {
+		t.Errorf("Returned '%#v' instead of an APIError", err)
+	}
+	if resp.Retries != 2 || atomic.LoadInt32(&hits) != 3 {
+		t.Errorf("Retried %d times with %d attempts", resp.Retries, hits)
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	for _, retry := range []bool{false, true} {
+		var hits int32
+		ts := newRetryTestServer(1, http.StatusServiceUnavailable, &hits)
+		client := NewClient(nil)
+		client.RetryPolicy = &RetryPolicy{
+			MaxAttempts:        2,
+			BaseDelay:          time.Millisecond,
+			RetryNonIdempotent: retry,
+		}
+		req, err := http.NewRequest("POST", ts.URL, strings.NewReader(`{"foo":"bar"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body map[string]string
+		_, err = client.Do(req, &body)
+		if retry && (err != nil || body["foo"] != "bar") {
+			t.Errorf("Retried POST returned '%v' with body %#v", err, body)
+		}
+		if !retry && err == nil {
+			t.Error("POST has been retried")
+		}
+		ts.Close()
+	}
+}
+
+func TestRetryDisabled(t *testing.T) {
+	var hits int32
+	ts := newRetryTestServer(1, http.StatusServiceUnavailable, &hits)
+	defer ts.Close()
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewClient(nil).Do(req, nil); err == nil {
+		t.Error("Request has been retried without a RetryPolicy")
+	}
+}
+
+func TestRetryDelayHonorsRateLimitReset(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+	req, _ := http.NewRequest("GET", "/", nil)
+	resp := &Response{Response: &http.Response{
+		StatusCode: statusCodeRateLimit,
+		Header:     http.Header{},
+	}}
+	wait, ok := p.delay(req, resp, &RateLimitError{Reset: 7}, 0)
+	if !ok || wait < 7*time.Second {
+		t.Errorf("delay was %v (%v) instead of at least 7s", wait, ok)
+	}
+	resp.Header.Set("Retry-After", "42")
+	wait, ok = p.delay(req, resp, &RateLimitError{Reset: 7}, 0)
+	if !ok || wait < 42*time.Second {
+		t.Errorf("delay was %v (%v) instead of at least 42s", wait, ok)
+	}
+	if _, ok := p.delay(req, nil, &RateLimitError{Reset: 7}, 0); ok {
+		t.Error("Requests rejected by the circuit breaker are retried")
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 4 * time.Second}
+	for retries, max := range []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second,
+	} {
+		if d := p.backoff(retries); d < max/2 || d > max {
+			t.Errorf("backoff(%d) was %v, not within [%v, %v]",
+				retries, d, max/2, max)
+		}
+	}
+}
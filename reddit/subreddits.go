@@ -0,0 +1,168 @@
+package reddit
+
+import (
+	"context"
+	"time"
+)
+
+// SubredditsService is the API Endpoint for subreddits
+type SubredditsService service
+
+const (
+	defaultStreamMinInterval = 5 * time.Second
+	defaultStreamMaxInterval = 2 * time.Minute
+	defaultStreamSeenSize    = 1000
+)
+
+// StreamOptions configure StreamLinks and StreamComments.
+type StreamOptions struct {
+	// Interval between polls while new items arrive.
+	// Defaults to 5 seconds.
+	MinInterval time.Duration
+
+	// The interval doubles after every poll without new items or
+	// with an error, up to MaxInterval. Defaults to 2 minutes.
+	MaxInterval time.Duration
+
+	// Number of fullnames remembered to deduplicate items.
+	// Defaults to 1000, values below 100 are raised to 100.
+	SeenSize int
+
+	// Don't send the items that already exist when the stream
+	// starts, only the ones that arrive afterwards.
+	SkipExisting bool
+}
+
+// StreamLinks polls the newest links of subreddit and sends every link
+// not seen before to the returned link channel, oldest first. Errors
+// are sent to the error channel, after which polling continues. It
+// buffers one error; further errors are dropped until it has been
+// received from, so callers that only read links don't block the
+// stream. Both channels are closed once ctx is done.
+// Requests go through the Client, so they share its rate limit budget.
+// opts may be nil.
+func (s *SubredditsService) StreamLinks(ctx context.Context, subreddit string, opts *StreamOptions) (<-chan *Link, <-chan error) {
+	return stream[*Link](ctx, s.client, subredditPath(subreddit, "new"), opts)
+}
+
+// StreamComments is like StreamLinks, but for the newest comments
+// of subreddit.
+func (s *SubredditsService) StreamComments(ctx context.Context, subreddit string, opts *StreamOptions) (<-chan *Comment, <-chan error) {
+	return stream[*Comment](ctx, s.client, subredditPath(subreddit, "comments"), opts)
+}
+
+// streamOptions returns opts with the defaults filled in.
+func streamOptions(opts *StreamOptions) StreamOptions {
+	o := StreamOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.MinInterval <= 0 {
+		o.MinInterval = defaultStreamMinInterval
+	}
+	if o.MaxInterval < o.MinInterval {
+		o.MaxInterval = defaultStreamMaxInterval
+		if o.MaxInterval < o.MinInterval {
+			o.MaxInterval = o.MinInterval
+		}
+	}
+	if o.SeenSize == 0 {
+		o.SeenSize = defaultStreamSeenSize
+	}
+	if o.SeenSize < maxListingLimit {
+		o.SeenSize = maxListingLimit
+	}
+	return o
+}
+
+// nextInterval returns the interval after a poll with the given
+// outcome: MinInterval if there was progress, else twice interval
+// up to MaxInterval.
+func (o *StreamOptions) nextInterval(interval time.Duration, progress bool) time.Duration {
+	if progress {
+		return o.MinInterval
+	}
+	interval *= 2
+	if interval > o.MaxInterval {
+		interval = o.MaxInterval
+	}
+	return interval
+}
+
+// stream polls the listing at path and sends its new items.
+func stream[T Thing](ctx context.Context, c *Client, path string, opts *StreamOptions) (<-chan T, <-chan error) {
+	o := streamOptions(opts)
+	items := make(chan T)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(items)
+		defer close(errs)
+		seen := newSeenSet(o.SeenSize)
+		interval := o.MinInterval
+		for first := true; ; first = false {
+			page, _, err := getListing[T](ctx, c, path, &ListOptions{Limit: maxListingLimit})
+			sent := 0
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				select {
+				case errs <- err:
+				default:
+				}
+			}
+			// Listings are sorted newest first.
+			for i := len(page) - 1; i >= 0; i-- {
+				if !seen.add(thingName(page[i])) || (first && o.SkipExisting) {
+					continue
+				}
+				select {
+				case items <- page[i]:
+					sent++
+				case <-ctx.Done():
+					return
+				}
+			}
+			interval = o.nextInterval(interval, sent > 0)
+			if err := sleepUntil(ctx, time.Now().Add(interval)); err != nil {
+				return
+			}
+		}
+	}()
+	return items, errs
+}
+
+// seenSet remembers the most recently added fullnames.
+type seenSet struct {
+	ring []Fullname
+	next int
+	set  map[Fullname]struct{}
+}
+
+func newSeenSet(size int) *seenSet {
+	return &seenSet{
+		ring: make([]Fullname, size),
+		set:  make(map[Fullname]struct{}, size),
+	}
+}
+
+// has reports whether name is in the set.
+func (s *seenSet) has(name Fullname) bool {
+	_, ok := s.set[name]
+	return ok
+}
+
+// add adds name to the set, evicting the oldest name if the set is
+// full. It reports false if name has already been in the set.
+func (s *seenSet) add(name Fullname) bool {
+	if s.has(name) {
+		return false
+	}
+	if old := s.ring[s.next]; old != "" {
+		delete(s.set, old)
+	}
+	s.ring[s.next] = name
+	s.next = (s.next + 1) % len(s.ring)
+	s.set[name] = struct{}{}
+	return true
+}
@@ -0,0 +1,115 @@
+package reddit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSubredditsStreamLinks(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		polls int
+	)
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/r/golang/new" {
+			t.Errorf("Path was '%s'", r.URL.Path)
+		}
+		mu.Lock()
+		polls++
+		n := polls
+		mu.Unlock()
+		if n == 2 {
+			w.WriteHeader(503)
+			fmt.Fprintln(w, `{"message": "Server fuckup","error": 503}`)
+			return
+		}
+		// Every poll has one more link, newest first.
+		var children []string
+		for i := n; i > 0; i-- {
+			children = append(children, fmt.Sprintf(`{"kind": "t3", "data": {"name": "t3_%d"}}`, i))
+		}
+		fmt.Fprintf(w, `{"kind": "Listing", "data": {"children": [%s]}}`, strings.Join(children, ","))
+	})
+	defer ts.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	links, errs := client.Subreddits.StreamLinks(ctx, "golang", &StreamOptions{
+		MinInterval: time.Millisecond,
+		MaxInterval: 2 * time.Millisecond,
+	})
+	var names []string
+	for len(names) < 4 {
+		select {
+		case l := <-links:
+			names = append(names, string(l.Name))
+		case err := <-errs:
+			if _, ok := err.(*APIError); !ok {
+				t.Errorf("Error was '%#v' instead of an APIError", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out")
+		}
+	}
+	cancel()
+	if s := strings.Join(names, ","); s != "t3_1,t3_2,t3_3,t3_4" {
+		t.Errorf("Streamed %s instead of t3_1,t3_2,t3_3,t3_4", s)
+	}
+	for range links {
+	}
+	for range errs {
+	}
+}
+
+func TestSubredditsStreamLinksUnreadErrors(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		polls int
+	)
+	client, ts := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		polls++
+		n := polls
+		mu.Unlock()
+		if n <= 3 {
+			w.WriteHeader(503)
+			fmt.Fprintln(w, `{"message": "Server fuckup","error": 503}`)
+			return
+		}
+		fmt.Fprintln(w, `{"kind": "Listing", "data": {"children": [{"kind": "t3", "data": {"name": "t3_1"}}]}}`)
+	})
+	defer ts.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	links, _ := client.Subreddits.StreamLinks(ctx, "golang", &StreamOptions{
+		MinInterval: time.Millisecond,
+		MaxInterval: time.Millisecond,
+	})
+	select {
+	case l := <-links:
+		if l.Name != "t3_1" {
+			t.Errorf("Streamed %s instead of t3_1", l.Name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out")
+	}
+}
+
+func TestSeenSet(t *testing.T) {
+	s := newSeenSet(2)
+	for i, test := range []struct {
+		name  Fullname
+		added bool
+	}{
+		{"t3_a", true}, {"t3_b", true}, {"t3_a", false},
+		{"t3_c", true}, {"t3_a", true}, {"t3_c", false},
+	} {
+		if added := s.add(test.name); added != test.added {
+			t.Errorf("Test(%d) add(%s) returned %v", i, test.name, added)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package reddit
+
+import "encoding/json"
+
+// Kind returns KindComment.
+func (*Comment) Kind() Kind { return KindComment }
+
+// Kind returns KindAccount.
+func (*Account) Kind() Kind { return KindAccount }
+
+// Kind returns KindLink.
+func (*Link) Kind() Kind { return KindLink }
+
+// Kind returns KindMessage.
+func (*Message) Kind() Kind { return KindMessage }
+
+// Kind returns KindSubreddit.
+func (*Subreddit) Kind() Kind { return KindSubreddit }
+
+// Kind returns KindAward.
+func (*Award) Kind() Kind { return KindAward }
+
+// Kind returns KindMore.
+func (*More) Kind() Kind { return KindMore }
+
+// UnknownThing holds a thing of a kind this package has no type for.
+// Its Data can be decoded by the caller.
+type UnknownThing struct {
+	ThingKind Kind
+	Data      json.RawMessage
+}
+
+// Kind returns the kind the thing has been sent with.
+func (t *UnknownThing) Kind() Kind { return t.ThingKind }
+
+// newThing returns a pointer to a new value of the concrete type for k.
+func newThing(k Kind) Thing {
+	switch k {
+	case KindComment:
+		return new(Comment)
+	case KindAccount:
+		return new(Account)
+	case KindLink:
+		return new(Link)
+	case KindMessage:
+		return new(Message)
+	case KindSubreddit:
+		return new(Subreddit)
+	case KindAward:
+		return new(Award)
+	case KindMore:
+		return new(More)
+	}
+	return nil
+}
+
+// decodeThing decodes data into the concrete type for k.
+func decodeThing(k Kind, data json.RawMessage) (Thing, error) {
+	t := newThing(k)
+	if t == nil {
+		return &UnknownThing{ThingKind: k, Data: data}, nil
+	}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// UnmarshalJSON decodes the kind and data of a thing and
+// sets Thing to the data decoded into the type for the kind.
+func (t *ListingThing) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Kind Kind            `json:"kind"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	thing, err := decodeThing(raw.Kind, raw.Data)
+	if err != nil {
+		return err
+	}
+	t.Kind, t.Data, t.Thing = raw.Kind, raw.Data, thing
+	return nil
+}
+
+// UnmarshalJSON decodes a listing of replies, or an empty string
+// for no replies.
+func (r *Replies) UnmarshalJSON(b []byte) error {
+	*r = Replies{}
+	if s := string(b); s == `""` || s == "null" {
+		return nil
+	}
+	var listing listingResponse
+	if err := json.Unmarshal(b, &listing); err != nil {
+		return err
+	}
+	for _, c := range listing.Data.Children {
+		switch v := c.Thing.(type) {
+		case *Comment:
+			r.Comments = append(r.Comments, v)
+		case *More:
+			if r.More == nil {
+				r.More = v
+				continue
+			}
+			r.More.Children = append(r.More.Children, v.Children...)
+			r.More.Count += v.Count
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes a listing of messages, or an empty string
+// for no replies.
+func (r *MessageReplies) UnmarshalJSON(b []byte) error {
+	*r = nil
+	if s := string(b); s == `""` || s == "null" {
+		return nil
+	}
+	var listing listingResponse
+	if err := json.Unmarshal(b, &listing); err != nil {
+		return err
+	}
+	messages, err := decodeListing[*Message](&listing.Data)
+	if err != nil {
+		return err
+	}
+	*r = messages
+	return nil
+}
+
+// thingName returns the fullname of t, or an empty
+// string if t has none.
+func thingName(t Thing) Fullname {
+	switch v := t.(type) {
+	case *Comment:
+		return v.Name
+	case *Account:
+		return Fullname(string(KindAccount) + "_" + v.ID)
+	case *Link:
+		return v.Name
+	case *Message:
+		return v.Name
+	case *Subreddit:
+		return v.Name
+	case *More:
+		return v.Name
+	}
+	return ""
+}
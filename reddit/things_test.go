@@ -0,0 +1,88 @@
+package reddit
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestListingThingDecoding(t *testing.T) {
+	var listing Listing
+	err := json.Unmarshal([]byte(`{"children": [
+		{"kind": "t1", "data": {"id": "c1", "body": "comment"}},
+		{"kind": "t2", "data": {"id": "a1", "name": "fooBar"}},
+		{"kind": "t3", "data": {"id": "l1", "title": "link"}},
+		{"kind": "t4", "data": {"id": "m1", "subject": "message"}},
+		{"kind": "t5", "data": {"id": "s1", "display_name": "golang"}},
+		{"kind": "t6", "data": {"award_id": "o", "name": "Verified Email"}},
+		{"kind": "more", "data": {"id": "c2", "children": ["c2", "c3"]}},
+		{"kind": "t9", "data": {"foo": "bar"}}
+	]}`), &listing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listing.Children) != 8 {
+		t.Fatalf("Decoded %d instead of 8 children", len(listing.Children))
+	}
+	for i, c := range listing.Children {
+		if c.Thing == nil {
+			t.Errorf("Child %d has not been decoded", i)
+			continue
+		}
+		if k := c.Thing.Kind(); k != c.Kind {
+			t.Errorf("Child %d decoded as %s instead of %s", i, k, c.Kind)
+		}
+		var ok bool
+		switch v := c.Thing.(type) {
+		case *Comment:
+			ok = v.Body == "comment"
+		case *Account:
+			ok = v.Name == "fooBar"
+		case *Link:
+			ok = v.Title == "link"
+		case *Message:
+			ok = v.Subject == "message"
+		case *Subreddit:
+			ok = v.DisplayName == "golang"
+		case *Award:
+			ok = v.Name == "Verified Email"
+		case *More:
+			ok = len(v.Children) == 2
+		case *UnknownThing:
+			ok = v.ThingKind == "t9" && string(v.Data) == `{"foo": "bar"}`
+		}
+		if !ok {
+			t.Errorf("Child %d decoded wrongly: %#v", i, c.Thing)
+		}
+	}
+}
+
+func TestListingThingDecodingError(t *testing.T) {
+	var thing ListingThing
+	if err := json.Unmarshal([]byte(`{"kind": "t3", "data": {"title": 5}}`), &thing); err == nil {
+		t.Error("No error for invalid data")
+	}
+}
+
+func TestDecodeListingTypes(t *testing.T) {
+	var listing Listing
+	err := json.Unmarshal([]byte(`{"children": [
+		{"kind": "t1", "data": {"id": "c1"}},
+		{"kind": "t1", "data": {"id": "c2"}},
+		{"kind": "more", "data": {"id": "c3"}}
+	]}`), &listing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comments, err := decodeListing[Comment](&listing)
+	if err != nil || len(comments) != 2 || comments[1].ID != "c2" {
+		t.Errorf("Decoded %#v (%v) as comments", comments, err)
+	}
+	things, err := decodeListing[Thing](&listing)
+	if err != nil || len(things) != 3 {
+		t.Errorf("Decoded %#v (%v) as things", things, err)
+	}
+	custom, err := decodeListing[struct{ ID string }](&listing)
+	if err != nil || len(custom) != 3 || custom[2].ID != "c3" {
+		t.Errorf("Decoded %#v (%v) as custom type", custom, err)
+	}
+}
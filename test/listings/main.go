@@ -1,12 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
 	"runtime"
 
-	"golang.org/x/net/context"
 	"golang.org/x/oauth2/clientcredentials"
 
 	"github.com/ikaros/go-reddit/reddit"
@@ -29,12 +29,12 @@ func main() {
 	http.DefaultClient.Transport = reddit.WrapHTTPTransport(rc.UserAgent,
 		http.DefaultTransport)
 
-	links, err := rc.Listings.ByID("t3_ffff0s")
+	links, _, err := rc.Listings.ByID("t3_ffff0s")
 	if err != nil {
 		fmt.Println(err)
 	}
 
-	links, err = rc.Listings.ByID("t3_5572gp")
+	links, _, err = rc.Listings.ByID("t3_5572gp")
 	if err != nil {
 		fmt.Println(err)
 	}